@@ -33,6 +33,7 @@ type ClientMessage struct {
 	//	*ClientMessage_ConfirmConfigUpdate
 	//	*ClientMessage_ConfirmEnabled
 	//	*ClientMessage_ConfirmDisabled
+	//	*ClientMessage_RejectConfigUpdate
 	Body isClientMessage_Body `protobuf_oneof:"body"`
 }
 
@@ -117,6 +118,13 @@ func (x *ClientMessage) GetConfirmDisabled() *ConfirmDisabledMessage {
 	return nil
 }
 
+func (x *ClientMessage) GetRejectConfigUpdate() *RejectConfigUpdateMessage {
+	if x, ok := x.GetBody().(*ClientMessage_RejectConfigUpdate); ok {
+		return x.RejectConfigUpdate
+	}
+	return nil
+}
+
 type isClientMessage_Body interface {
 	isClientMessage_Body()
 }
@@ -145,6 +153,10 @@ type ClientMessage_ConfirmDisabled struct {
 	ConfirmDisabled *ConfirmDisabledMessage `protobuf:"bytes,6,opt,name=confirm_disabled,json=confirmDisabled,proto3,oneof"`
 }
 
+type ClientMessage_RejectConfigUpdate struct {
+	RejectConfigUpdate *RejectConfigUpdateMessage `protobuf:"bytes,7,opt,name=reject_config_update,json=rejectConfigUpdate,proto3,oneof"`
+}
+
 func (*ClientMessage_ProbeSession) isClientMessage_Body() {}
 
 func (*ClientMessage_TaskExecutionResponse) isClientMessage_Body() {}
@@ -157,6 +169,8 @@ func (*ClientMessage_ConfirmEnabled) isClientMessage_Body() {}
 
 func (*ClientMessage_ConfirmDisabled) isClientMessage_Body() {}
 
+func (*ClientMessage_RejectConfigUpdate) isClientMessage_Body() {}
+
 type ServerMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -169,6 +183,8 @@ type ServerMessage struct {
 	//	*ServerMessage_EnableRequest
 	//	*ServerMessage_DisableRequest
 	//	*ServerMessage_NextEventRequest
+	//	*ServerMessage_ReconciliationResult
+	//	*ServerMessage_ReleaseFinalizer
 	Body isServerMessage_Body `protobuf_oneof:"body"`
 }
 
@@ -246,6 +262,20 @@ func (x *ServerMessage) GetNextEventRequest() *NextEventRequestMessage {
 	return nil
 }
 
+func (x *ServerMessage) GetReconciliationResult() *ReconciliationResultMessage {
+	if x, ok := x.GetBody().(*ServerMessage_ReconciliationResult); ok {
+		return x.ReconciliationResult
+	}
+	return nil
+}
+
+func (x *ServerMessage) GetReleaseFinalizer() *ReleaseFinalizerMessage {
+	if x, ok := x.GetBody().(*ServerMessage_ReleaseFinalizer); ok {
+		return x.ReleaseFinalizer
+	}
+	return nil
+}
+
 type isServerMessage_Body interface {
 	isServerMessage_Body()
 }
@@ -270,6 +300,14 @@ type ServerMessage_NextEventRequest struct {
 	NextEventRequest *NextEventRequestMessage `protobuf:"bytes,5,opt,name=next_event_request,json=nextEventRequest,proto3,oneof"`
 }
 
+type ServerMessage_ReconciliationResult struct {
+	ReconciliationResult *ReconciliationResultMessage `protobuf:"bytes,6,opt,name=reconciliation_result,json=reconciliationResult,proto3,oneof"`
+}
+
+type ServerMessage_ReleaseFinalizer struct {
+	ReleaseFinalizer *ReleaseFinalizerMessage `protobuf:"bytes,7,opt,name=release_finalizer,json=releaseFinalizer,proto3,oneof"`
+}
+
 func (*ServerMessage_TaskExecutionRequest) isServerMessage_Body() {}
 
 func (*ServerMessage_UpdateConfigRequest) isServerMessage_Body() {}
@@ -280,6 +318,10 @@ func (*ServerMessage_DisableRequest) isServerMessage_Body() {}
 
 func (*ServerMessage_NextEventRequest) isServerMessage_Body() {}
 
+func (*ServerMessage_ReconciliationResult) isServerMessage_Body() {}
+
+func (*ServerMessage_ReleaseFinalizer) isServerMessage_Body() {}
+
 var File_remote_work_processor_service_proto protoreflect.FileDescriptor
 
 var file_remote_work_processor_service_proto_rawDesc = []byte{
@@ -290,7 +332,7 @@ var file_remote_work_processor_service_proto_rawDesc = []byte{
 	0x2e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x1a, 0x15, 0x63,
 	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x15, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb6, 0x05, 0x0a, 0x0d,
+	0x73, 0x61, 0x67, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xad, 0x06, 0x0a, 0x0d,
 	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x62, 0x0a,
 	0x0d, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x5f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x73, 0x61, 0x70, 0x2e, 0x61, 0x75, 0x74, 0x6f, 0x70,
@@ -333,64 +375,86 @@ var file_remote_work_processor_service_proto_rawDesc = []byte{
 	0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x2e,
 	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c,
 	0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x63, 0x6f, 0x6e,
-	0x66, 0x69, 0x72, 0x6d, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x42, 0x06, 0x0a, 0x04,
-	0x62, 0x6f, 0x64, 0x79, 0x22, 0xd0, 0x04, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x7b, 0x0a, 0x16, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x65,
-	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x43, 0x2e, 0x73, 0x61, 0x70, 0x2e, 0x61, 0x75, 0x74,
-	0x6f, 0x70, 0x69, 0x6c, 0x6f, 0x74, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x6f,
-	0x72, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e,
-	0x54, 0x61, 0x73, 0x6b, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x14, 0x74,
-	0x61, 0x73, 0x6b, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x78, 0x0a, 0x15, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x63, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x42, 0x2e, 0x73, 0x61, 0x70, 0x2e, 0x61, 0x75, 0x74, 0x6f, 0x70, 0x69, 0x6c,
-	0x6f, 0x74, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x70,
-	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x13, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x65, 0x0a,
-	0x0e, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x73, 0x61, 0x70, 0x2e, 0x61, 0x75, 0x74, 0x6f,
-	0x70, 0x69, 0x6c, 0x6f, 0x74, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x6f, 0x72,
-	0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45,
-	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x68, 0x0a, 0x0f, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3d, 0x2e,
-	0x73, 0x61, 0x70, 0x2e, 0x61, 0x75, 0x74, 0x6f, 0x70, 0x69, 0x6c, 0x6f, 0x74, 0x2e, 0x72, 0x65,
-	0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73,
-	0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0e,
-	0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x6f,
-	0x0a, 0x12, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3f, 0x2e, 0x73, 0x61, 0x70,
+	0x66, 0x69, 0x72, 0x6d, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x75, 0x0a, 0x14,
+	0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x41, 0x2e, 0x73, 0x61, 0x70,
 	0x2e, 0x61, 0x75, 0x74, 0x6f, 0x70, 0x69, 0x6c, 0x6f, 0x74, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74,
 	0x65, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72,
-	0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x10, 0x6e,
-	0x65, 0x78, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42,
-	0x06, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x32, 0x99, 0x01, 0x0a, 0x1a, 0x52, 0x65, 0x6d, 0x6f,
-	0x74, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x7b, 0x0a, 0x07, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x12, 0x35, 0x2e, 0x73, 0x61, 0x70, 0x2e, 0x61, 0x75, 0x74, 0x6f, 0x70, 0x69, 0x6c, 0x6f,
-	0x74, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x70, 0x72,
-	0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e,
-	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x35, 0x2e, 0x73, 0x61, 0x70, 0x2e, 0x61,
-	0x75, 0x74, 0x6f, 0x70, 0x69, 0x6c, 0x6f, 0x74, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e,
-	0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x2e, 0x76,
-	0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x28,
-	0x01, 0x30, 0x01, 0x42, 0x88, 0x01, 0x0a, 0x30, 0x63, 0x6f, 0x6d, 0x2e, 0x73, 0x61, 0x70, 0x2e,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x12, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0xbc, 0x06, 0x0a, 0x0d,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x7b, 0x0a,
+	0x16, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x43, 0x2e,
+	0x73, 0x61, 0x70, 0x2e, 0x61, 0x75, 0x74, 0x6f, 0x70, 0x69, 0x6c, 0x6f, 0x74, 0x2e, 0x72, 0x65,
+	0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x48, 0x00, 0x52, 0x14, 0x74, 0x61, 0x73, 0x6b, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x78, 0x0a, 0x15, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x42, 0x2e, 0x73, 0x61, 0x70, 0x2e,
 	0x61, 0x75, 0x74, 0x6f, 0x70, 0x69, 0x6c, 0x6f, 0x74, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65,
 	0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x42, 0x1f, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65,
-	0x57, 0x6f, 0x72, 0x6b, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x31, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x53, 0x41, 0x50, 0x2f, 0x72, 0x65, 0x6d, 0x6f,
-	0x74, 0x65, 0x2d, 0x77, 0x6f, 0x72, 0x6b, 0x2d, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f,
-	0x72, 0x2f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x3b, 0x70, 0x62, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x13, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x65, 0x0a, 0x0e, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x73,
+	0x61, 0x70, 0x2e, 0x61, 0x75, 0x74, 0x6f, 0x70, 0x69, 0x6c, 0x6f, 0x74, 0x2e, 0x72, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x68, 0x0a, 0x0f, 0x64,
+	0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x3d, 0x2e, 0x73, 0x61, 0x70, 0x2e, 0x61, 0x75, 0x74, 0x6f, 0x70,
+	0x69, 0x6c, 0x6f, 0x74, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x6f, 0x72, 0x6b,
+	0x2e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69,
+	0x73, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x6f, 0x0a, 0x12, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x3f, 0x2e, 0x73, 0x61, 0x70, 0x2e, 0x61, 0x75, 0x74, 0x6f, 0x70, 0x69, 0x6c, 0x6f,
+	0x74, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x70, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x48, 0x00, 0x52, 0x10, 0x6e, 0x65, 0x78, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x7a, 0x0a, 0x15, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x63,
+	0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x43, 0x2e, 0x73, 0x61, 0x70, 0x2e, 0x61, 0x75, 0x74, 0x6f,
+	0x70, 0x69, 0x6c, 0x6f, 0x74, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x6f, 0x72,
+	0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x14, 0x72, 0x65,
+	0x63, 0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x6e, 0x0a, 0x11, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x66, 0x69,
+	0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3f, 0x2e,
+	0x73, 0x61, 0x70, 0x2e, 0x61, 0x75, 0x74, 0x6f, 0x70, 0x69, 0x6c, 0x6f, 0x74, 0x2e, 0x72, 0x65,
+	0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x46, 0x69,
+	0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x10, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a,
+	0x65, 0x72, 0x42, 0x06, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x32, 0x99, 0x01, 0x0a, 0x1a, 0x52,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x6f, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x7b, 0x0a, 0x07, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x35, 0x2e, 0x73, 0x61, 0x70, 0x2e, 0x61, 0x75, 0x74, 0x6f, 0x70,
+	0x69, 0x6c, 0x6f, 0x74, 0x2e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2e, 0x77, 0x6f, 0x72, 0x6b,
+	0x2e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x35, 0x2e, 0x73, 0x61,
+	0x70, 0x2e, 0x61, 0x75, 0x74, 0x6f, 0x70, 0x69, 0x6c, 0x6f, 0x74, 0x2e, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x88, 0x01, 0x0a, 0x30, 0x63, 0x6f, 0x6d, 0x2e, 0x73,
+	0x61, 0x70, 0x2e, 0x61, 0x75, 0x74, 0x6f, 0x70, 0x69, 0x6c, 0x6f, 0x74, 0x2e, 0x72, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x42, 0x1f, 0x52, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x31,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x53, 0x41, 0x50, 0x2f, 0x72,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x2d, 0x77, 0x6f, 0x72, 0x6b, 0x2d, 0x70, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x6f, 0x72, 0x2f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x3b, 0x70,
+	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -415,11 +479,14 @@ var file_remote_work_processor_service_proto_goTypes = []interface{}{
 	(*ConfirmConfigUpdateMessage)(nil),   // 5: sap.autopilot.remote.work.processor.v1.ConfirmConfigUpdateMessage
 	(*ConfirmEnabledMessage)(nil),        // 6: sap.autopilot.remote.work.processor.v1.ConfirmEnabledMessage
 	(*ConfirmDisabledMessage)(nil),       // 7: sap.autopilot.remote.work.processor.v1.ConfirmDisabledMessage
-	(*TaskExecutionRequestMessage)(nil),  // 8: sap.autopilot.remote.work.processor.v1.TaskExecutionRequestMessage
-	(*UpdateConfigRequestMessage)(nil),   // 9: sap.autopilot.remote.work.processor.v1.UpdateConfigRequestMessage
-	(*EnableRequestMessage)(nil),         // 10: sap.autopilot.remote.work.processor.v1.EnableRequestMessage
-	(*DisableRequestMessage)(nil),        // 11: sap.autopilot.remote.work.processor.v1.DisableRequestMessage
-	(*NextEventRequestMessage)(nil),      // 12: sap.autopilot.remote.work.processor.v1.NextEventRequestMessage
+	(*RejectConfigUpdateMessage)(nil),    // 8: sap.autopilot.remote.work.processor.v1.RejectConfigUpdateMessage
+	(*TaskExecutionRequestMessage)(nil),  // 9: sap.autopilot.remote.work.processor.v1.TaskExecutionRequestMessage
+	(*UpdateConfigRequestMessage)(nil),   // 10: sap.autopilot.remote.work.processor.v1.UpdateConfigRequestMessage
+	(*EnableRequestMessage)(nil),         // 11: sap.autopilot.remote.work.processor.v1.EnableRequestMessage
+	(*DisableRequestMessage)(nil),        // 12: sap.autopilot.remote.work.processor.v1.DisableRequestMessage
+	(*NextEventRequestMessage)(nil),      // 13: sap.autopilot.remote.work.processor.v1.NextEventRequestMessage
+	(*ReconciliationResultMessage)(nil),  // 14: sap.autopilot.remote.work.processor.v1.ReconciliationResultMessage
+	(*ReleaseFinalizerMessage)(nil),      // 15: sap.autopilot.remote.work.processor.v1.ReleaseFinalizerMessage
 }
 var file_remote_work_processor_service_proto_depIdxs = []int32{
 	2,  // 0: sap.autopilot.remote.work.processor.v1.ClientMessage.probe_session:type_name -> sap.autopilot.remote.work.processor.v1.ProbeSessionMessage
@@ -428,18 +495,21 @@ var file_remote_work_processor_service_proto_depIdxs = []int32{
 	5,  // 3: sap.autopilot.remote.work.processor.v1.ClientMessage.confirm_config_update:type_name -> sap.autopilot.remote.work.processor.v1.ConfirmConfigUpdateMessage
 	6,  // 4: sap.autopilot.remote.work.processor.v1.ClientMessage.confirm_enabled:type_name -> sap.autopilot.remote.work.processor.v1.ConfirmEnabledMessage
 	7,  // 5: sap.autopilot.remote.work.processor.v1.ClientMessage.confirm_disabled:type_name -> sap.autopilot.remote.work.processor.v1.ConfirmDisabledMessage
-	8,  // 6: sap.autopilot.remote.work.processor.v1.ServerMessage.task_execution_request:type_name -> sap.autopilot.remote.work.processor.v1.TaskExecutionRequestMessage
-	9,  // 7: sap.autopilot.remote.work.processor.v1.ServerMessage.update_config_request:type_name -> sap.autopilot.remote.work.processor.v1.UpdateConfigRequestMessage
-	10, // 8: sap.autopilot.remote.work.processor.v1.ServerMessage.enable_request:type_name -> sap.autopilot.remote.work.processor.v1.EnableRequestMessage
-	11, // 9: sap.autopilot.remote.work.processor.v1.ServerMessage.disable_request:type_name -> sap.autopilot.remote.work.processor.v1.DisableRequestMessage
-	12, // 10: sap.autopilot.remote.work.processor.v1.ServerMessage.next_event_request:type_name -> sap.autopilot.remote.work.processor.v1.NextEventRequestMessage
-	0,  // 11: sap.autopilot.remote.work.processor.v1.RemoteWorkProcessorService.Session:input_type -> sap.autopilot.remote.work.processor.v1.ClientMessage
-	1,  // 12: sap.autopilot.remote.work.processor.v1.RemoteWorkProcessorService.Session:output_type -> sap.autopilot.remote.work.processor.v1.ServerMessage
-	12, // [12:13] is the sub-list for method output_type
-	11, // [11:12] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	8,  // 6: sap.autopilot.remote.work.processor.v1.ClientMessage.reject_config_update:type_name -> sap.autopilot.remote.work.processor.v1.RejectConfigUpdateMessage
+	9,  // 7: sap.autopilot.remote.work.processor.v1.ServerMessage.task_execution_request:type_name -> sap.autopilot.remote.work.processor.v1.TaskExecutionRequestMessage
+	10, // 8: sap.autopilot.remote.work.processor.v1.ServerMessage.update_config_request:type_name -> sap.autopilot.remote.work.processor.v1.UpdateConfigRequestMessage
+	11, // 9: sap.autopilot.remote.work.processor.v1.ServerMessage.enable_request:type_name -> sap.autopilot.remote.work.processor.v1.EnableRequestMessage
+	12, // 10: sap.autopilot.remote.work.processor.v1.ServerMessage.disable_request:type_name -> sap.autopilot.remote.work.processor.v1.DisableRequestMessage
+	13, // 11: sap.autopilot.remote.work.processor.v1.ServerMessage.next_event_request:type_name -> sap.autopilot.remote.work.processor.v1.NextEventRequestMessage
+	14, // 12: sap.autopilot.remote.work.processor.v1.ServerMessage.reconciliation_result:type_name -> sap.autopilot.remote.work.processor.v1.ReconciliationResultMessage
+	15, // 13: sap.autopilot.remote.work.processor.v1.ServerMessage.release_finalizer:type_name -> sap.autopilot.remote.work.processor.v1.ReleaseFinalizerMessage
+	0,  // 14: sap.autopilot.remote.work.processor.v1.RemoteWorkProcessorService.Session:input_type -> sap.autopilot.remote.work.processor.v1.ClientMessage
+	1,  // 15: sap.autopilot.remote.work.processor.v1.RemoteWorkProcessorService.Session:output_type -> sap.autopilot.remote.work.processor.v1.ServerMessage
+	15, // [15:16] is the sub-list for method output_type
+	14, // [14:15] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_remote_work_processor_service_proto_init() }
@@ -482,6 +552,7 @@ func file_remote_work_processor_service_proto_init() {
 		(*ClientMessage_ConfirmConfigUpdate)(nil),
 		(*ClientMessage_ConfirmEnabled)(nil),
 		(*ClientMessage_ConfirmDisabled)(nil),
+		(*ClientMessage_RejectConfigUpdate)(nil),
 	}
 	file_remote_work_processor_service_proto_msgTypes[1].OneofWrappers = []interface{}{
 		(*ServerMessage_TaskExecutionRequest)(nil),
@@ -489,6 +560,8 @@ func file_remote_work_processor_service_proto_init() {
 		(*ServerMessage_EnableRequest)(nil),
 		(*ServerMessage_DisableRequest)(nil),
 		(*ServerMessage_NextEventRequest)(nil),
+		(*ServerMessage_ReconciliationResult)(nil),
+		(*ServerMessage_ReleaseFinalizer)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -0,0 +1,10 @@
+package main
+
+// The built-in executors register themselves into the executors.DefaultRegistry once their package is imported.
+// Custom executors are added the same way: import their package here, or register them from an init function
+// of another file in this package. See the "Custom executors" section of the README.
+import (
+	_ "github.com/SAP/remote-work-processor/internal/executors/http"
+	_ "github.com/SAP/remote-work-processor/internal/executors/script"
+	_ "github.com/SAP/remote-work-processor/internal/executors/void"
+)
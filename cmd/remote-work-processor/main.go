@@ -20,21 +20,30 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	pb "github.com/SAP/remote-work-processor/build/proto/generated"
+	"github.com/SAP/remote-work-processor/internal/executors"
+	"github.com/SAP/remote-work-processor/internal/executors/kubernetes"
 	"github.com/SAP/remote-work-processor/internal/grpc"
 	"github.com/SAP/remote-work-processor/internal/grpc/processors"
+	"github.com/SAP/remote-work-processor/internal/health"
 	"github.com/SAP/remote-work-processor/internal/kubernetes/controller"
+	"github.com/SAP/remote-work-processor/internal/kubernetes/dynamic"
 	meta "github.com/SAP/remote-work-processor/internal/kubernetes/metadata"
+	"github.com/SAP/remote-work-processor/internal/metrics"
 	"github.com/SAP/remote-work-processor/internal/opt"
+	"github.com/SAP/remote-work-processor/internal/tracing"
 	"github.com/SAP/remote-work-processor/internal/utils"
+	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
-	"log"
+	stdlog "log"
 	"os"
 	"os/signal"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"syscall"
+	"time"
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -59,74 +68,108 @@ func main() {
 		return
 	}
 
-	rootCtx, _ := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	if opts.CleanupFinalizers {
+		cleanupFinalizers()
+		return
+	}
 
 	rwpMetadata := meta.LoadMetadata(opts.InstanceId, Version)
-	grpcClient := grpc.NewClient(rwpMetadata, opts.StandaloneMode)
+	// every line logged on behalf of the session, including the ones of the tasks, carries its ID
+	logger := ctrl.Log.WithValues("session_id", rwpMetadata.SessionID())
+
+	rootCtx, _ := signal.NotifyContext(ctrl.LoggerInto(context.Background(), logger), os.Interrupt, syscall.SIGTERM)
+	// aborts the in-flight tasks, on shutdown or once the session with the server can no longer be maintained
+	tasksCtx, cancelTasks := context.WithCancel(rootCtx)
+	defer cancelTasks()
+	// outlives the tasks, so that they can report their results; stops the k8s manager as well
+	sessionCtx, stopSession := context.WithCancel(ctrl.LoggerInto(context.Background(), logger))
+	defer stopSession()
+
+	metrics.Serve(sessionCtx, opts.MetricsAddr)
+
+	shutdownTracing, err := tracing.Setup(rootCtx, tracing.Exporter(opts.TraceExporter), opts.TraceEndpoint, Version)
+	if err != nil {
+		logger.Error(err, "Could not set up tracing")
+		os.Exit(1)
+	}
+	defer flushTraces(logger, shutdownTracing)
+
+	grpcClient := grpc.NewClient(sessionCtx, rwpMetadata, opts.StandaloneMode)
+	retryConfig := utils.CreateRetryConfig(opts.RetryInterval, opts.RetryStrategy.Unmarshall(), opts.MaxConnRetries)
+	supervisor := grpc.NewSessionSupervisor(grpcClient, rwpMetadata.SessionID(), retryConfig)
 	var drainChan chan struct{}
 
 	var factory processors.ProcessorFactory
+	var dynamicClient *dynamic.Client
+	var watchConfig health.WatchConfigState
 
 	if opts.StandaloneMode {
-		factory = processors.NewStandaloneProcessorFactory()
+		factory = processors.NewStandaloneProcessorFactory(executors.DefaultRegistry)
 	} else {
 		config := getKubeConfig()
 		scheme := runtime.NewScheme()
 		utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 		//+kubebuilder:scaffold:scheme
 
+		dynamicClient, err = dynamic.NewDynamicClient(config)
+		if err != nil {
+			logger.Error(err, "Could not create dynamic client")
+			os.Exit(1)
+		}
+
 		drainChan = make(chan struct{}, 1)
-		engine := controller.CreateManagerEngine(scheme, config, grpcClient)
-		factory = processors.NewKubernetesProcessorFactory(engine, drainChan)
+		engine := controller.CreateManagerEngine(scheme, config, dynamicClient, grpcClient)
+		if opts.LeaderElect {
+			// every replica keeps serving tasks, only the reconciliation is up to the leader
+			engine.EnableLeaderElection(opts.LeaderElectID)
+		}
+		factory = processors.NewKubernetesProcessorFactory(engine, executors.DefaultRegistry, drainChan)
+		// the manager keeps running while disconnected, pending reconciliation events are sent once reconnected
+		supervisor.OnSessionStarted(engine.ResumeEvents)
+		watchConfig = engine
 	}
 
-	connAttemptChan := make(chan struct{}, 1)
-	connAttemptChan <- struct{}{}
-	retryConfig := utils.CreateRetryConfig(opts.RetryInterval, opts.RetryStrategy.Unmarshall(), opts.MaxConnRetries, connAttemptChan)
-
-Loop:
-	for retryConfig.CanRetry() {
-		select {
-		case <-rootCtx.Done():
-			log.Println("Received cancellation signal. Stopping Remote Work Processor...")
-			break Loop
-		case <-connAttemptChan:
-			err := grpcClient.InitSession(rootCtx, rwpMetadata.SessionID())
-			if err != nil {
-				utils.Retry(rootCtx, retryConfig, err)
-			}
-		default:
-			operation, err := grpcClient.ReceiveMsg()
-			if err != nil {
-				utils.Retry(rootCtx, retryConfig, err)
-				continue
-			}
-			if operation == nil {
-				// this flow is when the gRPC connection is closed by the server
-				break Loop
-			}
-
-			log.Printf("Creating processor for operation: %T\n", operation.Body)
-			processor, err := factory.CreateProcessor(operation)
-			if err != nil {
-				log.Printf("error creating operation processor: %v\n", err)
-				continue
-			}
-
-			msg, err := processor.Process(rootCtx)
-			if err != nil {
-				utils.Retry(rootCtx, retryConfig, fmt.Errorf("error processing operation: %v", err))
-				continue
-			}
-			if msg == nil {
-				continue
-			}
-
-			if err = grpcClient.Send(msg); err != nil {
-				utils.Retry(rootCtx, retryConfig, err)
-			}
+	health.Serve(sessionCtx, opts.HealthAddr, health.Probes{
+		Loop:        supervisor,
+		Session:     grpcClient,
+		WatchConfig: watchConfig,
+		IsEnabled:   factory.IsEnabled,
+	}, opts.DebugStatus)
+
+	// the executor depends on the dynamic client, hence it cannot register itself (the client is nil in standalone mode);
+	// a custom executor registered from an init function takes precedence
+	executors.RegisterIfAbsent(pb.TaskType_TASK_TYPE_KUBERNETES_API_REQUEST, func() executors.Executor {
+		return kubernetes.NewKubernetesApiRequestExecutor(dynamicClient)
+	}, executors.DefaultTaskVersion)
+	logger.Info("Supported task types", "task_types", fmt.Sprint(executors.DefaultRegistry.SupportedTypes()))
+	if replaced := executors.DefaultRegistry.Replaced(); len(replaced) > 0 {
+		logger.Info("Built-in executors replaced by custom ones", "task_types", fmt.Sprint(replaced))
+	}
+
+	dispatcher := processors.NewDispatcher(tasksCtx, grpcClient, opts.TaskWorkers, opts.TaskQueueSize)
+	go func() {
+		// on shutdown, the session is closed only after the aborted tasks have reported their results
+		<-tasksCtx.Done()
+		dispatcher.Wait()
+		stopSession()
+	}()
+
+	err = supervisor.Run(sessionCtx, func(ctx context.Context, operation *pb.ServerMessage) error {
+		ctrl.LoggerFrom(ctx).V(1).Info("Creating processor for operation", "operation", fmt.Sprintf("%T", operation.Body))
+		processor, err := factory.CreateProcessor(operation)
+		if err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "error creating operation processor")
+			return nil
 		}
+		return dispatcher.Dispatch(ctx, processor)
+	})
+	if err != nil {
+		logger.Error(err, "Session with the server ended")
 	}
+	logger.Info("Stopping Remote Work Processor...")
+	cancelTasks()
+	stopSession()
+	dispatcher.Wait()
 
 	if !opts.StandaloneMode {
 		// wait for context cancellation to be propagated to the k8s manager
@@ -142,14 +185,44 @@ func setupFlagsAndLogger() *opt.Options {
 	zapOpts.BindFlags(flag.CommandLine)
 
 	flag.Parse()
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&zapOpts)))
+	// scrubs the secrets of sensitive tasks in progress from the log output, including the one of libraries
+	// still logging through the standard logger
+	out := executors.NewRedactingWriter(os.Stderr)
+	stdlog.SetOutput(out)
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&zapOpts), zap.WriteTo(out)))
 	return opts
 }
 
+func flushTraces(logger logr.Logger, shutdown func(context.Context) error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdown(ctx); err != nil {
+		logger.Error(err, "Could not flush traces")
+	}
+}
+
+func cleanupFinalizers() {
+	ctx, stop := signal.NotifyContext(ctrl.LoggerInto(context.Background(), ctrl.Log), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dynamicClient, err := dynamic.NewDynamicClient(getKubeConfig())
+	if err != nil {
+		ctrl.Log.Error(err, "Could not create dynamic client")
+		os.Exit(1)
+	}
+	ctrl.Log.Info("Removing finalizers...")
+	if err := controller.CleanupFinalizers(ctx, dynamicClient); err != nil {
+		ctrl.Log.Error(err, "Could not remove all finalizers")
+		os.Exit(1)
+	}
+	ctrl.Log.Info("Finalizers removed")
+}
+
 func getKubeConfig() *rest.Config {
 	config, err := rest.InClusterConfig()
 	if err != nil {
-		log.Fatalln("Could not create kubeconfig:", err)
+		ctrl.Log.Error(err, "Could not create kubeconfig")
+		os.Exit(1)
 	}
 	return config
 }
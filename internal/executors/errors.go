@@ -23,6 +23,11 @@ func NewNonRetryableError(format string, args ...any) *NonRetryableError {
 	}
 }
 
+// NonRetryable wraps the error, keeping its message, so that the task is not retried.
+func NonRetryable(cause error) error {
+	return NewNonRetryableError("%s", cause.Error()).WithCause(cause)
+}
+
 func (err *NonRetryableError) WithCause(e error) *NonRetryableError {
 	err.cause = e
 	return err
@@ -1,6 +1,7 @@
 package executors
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"strconv"
@@ -15,13 +16,22 @@ type Context interface {
 	GetList(k string) ([]string, error)
 	GetBoolean(k string) (bool, error)
 	GetStore() map[string]string
+	GetTaskVersion() int32
+	IsSensitive() bool
+	Redact(s string) string
+	GetContext() context.Context
 }
 
 type ExecutorContext struct {
-	input map[string]string
-	store map[string]string
+	input       map[string]string
+	store       map[string]string
+	taskVersion int32
+	redactor    *Redactor
+	ctx         context.Context
 }
 
+type ExecutorContextOption func(*ExecutorContext)
+
 var (
 	bools = map[string]bool{
 		"true":  true,
@@ -29,13 +39,47 @@ var (
 	}
 )
 
-func NewExecutorContext(input map[string]string, store map[string]string) Context {
+func NewExecutorContext(input map[string]string, store map[string]string, opts ...ExecutorContextOption) Context {
 	if store == nil {
 		store = make(map[string]string)
 	}
-	return &ExecutorContext{
-		input: input,
-		store: store,
+	ctx := &ExecutorContext{
+		input:       input,
+		store:       store,
+		taskVersion: DefaultTaskVersion,
+		ctx:         context.Background(),
+	}
+
+	for _, opt := range opts {
+		opt(ctx)
+	}
+	return ctx
+}
+
+// TaskVersion sets the version of the input contract the task has been created for.
+// The zero value, sent by servers unaware of task versions, stands for the DefaultTaskVersion.
+func TaskVersion(v int32) ExecutorContextOption {
+	return func(ctx *ExecutorContext) {
+		if v != 0 {
+			ctx.taskVersion = v
+		}
+	}
+}
+
+// Sensitive marks the task input as containing secrets, which must not make it to the logs or the task result.
+func Sensitive(isSensitive bool) ExecutorContextOption {
+	return func(ctx *ExecutorContext) {
+		if isSensitive {
+			ctx.redactor = NewRedactor(ctx.input)
+		}
+	}
+}
+
+// TaskContext sets the context which is cancelled once the task has to be aborted, e.g. on shutdown.
+// Executors are expected to pass it on to all their blocking operations.
+func TaskContext(ctx context.Context) ExecutorContextOption {
+	return func(c *ExecutorContext) {
+		c.ctx = ctx
 	}
 }
 
@@ -118,3 +162,23 @@ func (e *ExecutorContext) GetBoolean(k string) (bool, error) {
 func (e *ExecutorContext) GetStore() map[string]string {
 	return e.store
 }
+
+func (e *ExecutorContext) GetTaskVersion() int32 {
+	return e.taskVersion
+}
+
+func (e *ExecutorContext) IsSensitive() bool {
+	return e.redactor != nil
+}
+
+// Redact scrubs the secrets of a sensitive task from the string. Strings of other tasks are returned unchanged.
+func (e *ExecutorContext) Redact(s string) string {
+	if e.redactor == nil {
+		return s
+	}
+	return e.redactor.Redact(s)
+}
+
+func (e *ExecutorContext) GetContext() context.Context {
+	return e.ctx
+}
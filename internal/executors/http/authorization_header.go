@@ -2,8 +2,8 @@ package http
 
 import (
 	"github.com/SAP/remote-work-processor/internal/executors"
-	"log"
 	"regexp"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const (
@@ -17,11 +17,12 @@ var iasTokenUrlRegex = regexp.MustCompile(IasTokenUrlPattern)
 // OAuth 2.0 will be added later
 
 func CreateAuthorizationHeader(params *HttpRequestParameters) (string, error) {
-	log.Println("HTTP Client: creating authorization header...")
+	logger := log.FromContext(params.GetContext())
+	logger.V(1).Info("HTTP Client: creating authorization header...")
 	authHeader := params.GetAuthorizationHeader()
 
 	if authHeader != "" {
-		log.Println("HTTP Client: using raw authorization header value")
+		logger.V(1).Info("HTTP Client: using raw authorization header value")
 		return authHeader, nil
 	}
 
@@ -31,24 +32,24 @@ func CreateAuthorizationHeader(params *HttpRequestParameters) (string, error) {
 
 	if tokenUrl != "" {
 		if user != "" && iasTokenUrlRegex.Match([]byte(tokenUrl)) {
-			log.Println("HTTP Client: using IAS Authorization Header...")
-			return NewIasAuthorizationHeader(tokenUrl, user, params.GetCertificateAuthentication().GetClientCertificate()).Generate()
+			logger.V(1).Info("HTTP Client: using IAS Authorization Header...")
+			return NewIasAuthorizationHeader(params).Generate()
 		}
-		log.Println("HTTP Client: using OAuth Authorization Header...")
+		logger.V(1).Info("HTTP Client: using OAuth Authorization Header...")
 		return NewOAuthHeaderGenerator(params).GenerateWithCacheAside()
 	}
 
 	if user != "" {
-		log.Println("HTTP Client: using basic auth...")
-		return NewBasicAuthorizationHeader(user, pass).Generate()
+		logger.V(1).Info("HTTP Client: using basic auth...")
+		return NewBasicAuthorizationHeader(params.GetContext(), user, pass).Generate()
 	}
 
 	if noAuthorizationRequired(params) {
-		log.Println("HTTP Client: not using authorization...")
+		logger.V(1).Info("HTTP Client: not using authorization...")
 		return "", nil
 	}
 
-	log.Println("HTTP Client: failed to determine auth header...")
+	logger.Info("HTTP Client: failed to determine auth header...")
 	return "", executors.NewNonRetryableError("Input values for the authentication-related keys " +
 		"(user, password & authorizationHeader) are not combined properly.")
 }
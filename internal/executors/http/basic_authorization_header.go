@@ -1,25 +1,29 @@
 package http
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
-	"log"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 type basicAuthorizationHeader struct {
 	username string
 	password string
+	ctx      context.Context
 }
 
-func NewBasicAuthorizationHeader(u string, p string) AuthorizationHeaderGenerator {
+func NewBasicAuthorizationHeader(ctx context.Context, u string, p string) AuthorizationHeaderGenerator {
 	return &basicAuthorizationHeader{
 		username: u,
 		password: p,
+		ctx:      ctx,
 	}
 }
 
 func (h *basicAuthorizationHeader) Generate() (string, error) {
-	log.Println("Basic Authorization Header: generating auth header...")
+	log.FromContext(h.ctx).V(1).Info("Basic Authorization Header: generating auth header...")
 	encoded := base64.StdEncoding.EncodeToString(
 		fmt.Appendf(nil, "%s:%s", h.username, h.password),
 	)
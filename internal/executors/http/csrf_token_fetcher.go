@@ -1,9 +1,13 @@
 package http
 
 import (
+	"context"
+	"github.com/SAP/remote-work-processor/internal/tracing"
 	"github.com/SAP/remote-work-processor/internal/utils"
-	"log"
+	"go.opentelemetry.io/otel/trace"
 	"net/http"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"strings"
 )
 
 const CsrfVerb = "fetch"
@@ -15,6 +19,9 @@ type csrfTokenFetcher struct {
 	csrfUrl          string
 	headers          map[string]string
 	succeedOnTimeout bool
+	sensitive        bool
+	omitBody         bool
+	ctx              context.Context
 }
 
 func NewCsrfTokenFetcher(p *HttpRequestParameters, authHeader string) TokenFetcher {
@@ -23,26 +30,34 @@ func NewCsrfTokenFetcher(p *HttpRequestParameters, authHeader string) TokenFetch
 		csrfUrl:          p.csrfUrl,
 		headers:          createCsrfHeaders(authHeader),
 		succeedOnTimeout: p.succeedOnTimeout,
+		sensitive:        p.sensitive,
+		omitBody:         p.omitBodyInErrorMessage,
+		ctx:              p.ctx,
 	}
 }
 
-func (f *csrfTokenFetcher) Fetch() (string, error) {
-	log.Println("CSRF token fetcher: fetching new CSRF token from:", f.csrfUrl)
+func (f *csrfTokenFetcher) Fetch() (token string, err error) {
+	var span trace.Span
+	f.ctx, span = tracing.Start(f.ctx, "csrfTokenFetcher.Fetch")
+	defer func() { tracing.EndWithError(span, spanError(err, f.sensitive)) }()
+
+	logger := log.FromContext(f.ctx)
+	logger.Info("CSRF token fetcher: fetching new CSRF token", urlLogValues(f.csrfUrl, f.sensitive)...)
 	params, _ := f.createRequestParameters()
 
 	resp, err := f.HttpExecutor.ExecuteWithParameters(params)
 	if err != nil {
-		log.Println("CSRF token fetcher: failed to fetch CSRF token:", err)
+		logger.Error(err, "CSRF token fetcher: failed to fetch CSRF token")
 		return "", &CsrfError{ResponseBody: "<empty>", StatusCode: "-1", TheError: err.Error()}
 	}
 
-	for key, value := range resp.Headers {
+	for key, values := range resp.header {
 		if utils.Contains(csrfTokenHeaders, key) {
-			return value, nil
+			return strings.Join(values, ", "), nil
 		}
 	}
 
-	log.Println("CSRF token fetcher: CSRF token header not found in response")
+	logger.Info("CSRF token fetcher: CSRF token header not found in response")
 	return "", &CsrfError{ResponseBody: resp.Content, StatusCode: resp.StatusCode, TheError: "missing CSRF header in response"}
 }
 
@@ -59,7 +74,8 @@ func createCsrfHeaders(authHeader string) HttpHeaders {
 }
 
 func (f *csrfTokenFetcher) createRequestParameters() (*HttpRequestParameters, error) {
-	return NewHttpRequestParameters(http.MethodGet, f.csrfUrl, WithHeaders(f.headers))
+	return NewHttpRequestParameters(http.MethodGet, f.csrfUrl, WithHeaders(f.headers), WithContext(f.ctx),
+		withSensitivity(f.sensitive, f.omitBody))
 }
 
 type CsrfError struct {
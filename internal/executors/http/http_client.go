@@ -1,25 +1,27 @@
 package http
 
 import (
-	"log"
+	"context"
 	"net/http"
 	"time"
 
 	"github.com/SAP/remote-work-processor/internal/executors/http/tls"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const (
 	DefaultHttpRequestTimeout = 3 * time.Second
 )
 
-func CreateHttpClient(timeoutInS uint64, certAuth *tls.CertificateAuthentication) (*http.Client, error) {
-	log.Println("HTTP Client: creating HTTP Client...")
+func CreateHttpClient(ctx context.Context, timeoutInS uint64, certAuth *tls.CertificateAuthentication) (*http.Client, error) {
+	logger := log.FromContext(ctx)
+	logger.V(1).Info("HTTP Client: creating HTTP Client...")
 	var tp http.RoundTripper
 	if certAuth != nil {
 		var err error
 
-		log.Println("HTTP Client: creating TLS transport...")
-		tp, err = tls.NewTLSConfigurationProvider(certAuth).CreateTransport()
+		logger.V(1).Info("HTTP Client: creating TLS transport...")
+		tp, err = tls.NewTLSConfigurationProvider(ctx, certAuth).CreateTransport()
 		if err != nil {
 			return nil, err
 		}
@@ -35,7 +37,7 @@ func CreateHttpClient(timeoutInS uint64, certAuth *tls.CertificateAuthentication
 	} else {
 		c.Timeout = time.Duration(timeoutInS) * time.Second
 	}
-	log.Println("HTTP Client: using timeout:", c.Timeout.String())
+	logger.V(1).Info("HTTP Client: using timeout", "timeout", c.Timeout.String())
 
 	return c, nil
 }
@@ -1,10 +1,10 @@
 package http
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net"
 	"net/http"
 	"net/http/httptrace"
@@ -14,8 +14,19 @@ import (
 
 	pb "github.com/SAP/remote-work-processor/build/proto/generated"
 	"github.com/SAP/remote-work-processor/internal/executors"
+	"github.com/SAP/remote-work-processor/internal/metrics"
+	"github.com/SAP/remote-work-processor/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+func init() {
+	executors.Register(pb.TaskType_TASK_TYPE_HTTP, func() executors.Executor {
+		return NewDefaultHttpRequestExecutor()
+	}, taskVersionSingleValueHeaders, taskVersionMultiValueHeaders)
+}
+
 type HttpExecutor interface {
 	ExecuteWithParameters(*HttpRequestParameters) (*HttpResponse, error)
 }
@@ -29,10 +40,11 @@ func NewDefaultHttpRequestExecutor() *HttpRequestExecutor {
 }
 
 func (e *HttpRequestExecutor) Execute(ctx executors.Context) *executors.ExecutorResult {
-	log.Println("Executing HttpRequest command...")
+	logger := log.FromContext(ctx.GetContext())
+	logger.Info("Executing HttpRequest command...")
 	params, err := NewHttpRequestParametersFromContext(ctx)
 	if err != nil {
-		log.Println("Could not create HTTP request params: returning Task state Failed Non-Retryable Error with:", err)
+		logger.Error(err, "Could not create HTTP request params: returning Task state Failed Non-Retryable Error")
 		return executors.NewExecutorResult(
 			executors.Status(pb.TaskExecutionResponseMessage_TASK_STATE_FAILED_NON_RETRYABLE),
 			executors.Error(err),
@@ -41,23 +53,37 @@ func (e *HttpRequestExecutor) Execute(ctx executors.Context) *executors.Executor
 
 	resp, err := e.ExecuteWithParameters(params)
 	if err != nil {
-		if errors.Is(err, &executors.RetryableError{}) {
-			log.Println("Returning Task state Failed Retryable Error...")
+		var retryable *executors.RetryableError
+		if errors.As(err, &retryable) {
+			logger.Info("Returning Task state Failed Retryable Error...")
 			return executors.NewExecutorResult(
 				executors.Status(pb.TaskExecutionResponseMessage_TASK_STATE_FAILED_RETRYABLE),
 				executors.Error(err),
 			)
 		}
-		log.Println("Returning Task state Failed Non-Retryable Error...")
+		logger.Info("Returning Task state Failed Non-Retryable Error...")
 		return executors.NewExecutorResult(
 			executors.Status(pb.TaskExecutionResponseMessage_TASK_STATE_FAILED_NON_RETRYABLE),
 			executors.Error(err),
 		)
 	}
 
+	if resp.successful && params.responseBodyTransformer != "" {
+		transformed, err := transformResponseBody(params.ctx, params.responseBodyTransformer, resp.Content, params.omitBodyInErrorMessage)
+		if err != nil {
+			logger.Error(err, "Could not transform response body: returning Task state Failed Non-Retryable Error")
+			return executors.NewExecutorResult(
+				executors.Output(resp.ToMap()),
+				executors.Status(pb.TaskExecutionResponseMessage_TASK_STATE_FAILED_NON_RETRYABLE),
+				executors.Error(err),
+			)
+		}
+		resp.TransformedBody = transformed
+	}
+
 	m := resp.ToMap()
 	if !resp.successful {
-		log.Println("Returning Task state Failed Retryable Error from HTTP response...")
+		logger.Info("Returning Task state Failed Retryable Error from HTTP response...")
 		return executors.NewExecutorResult(
 			executors.Output(m),
 			executors.Status(pb.TaskExecutionResponseMessage_TASK_STATE_FAILED_RETRYABLE),
@@ -65,15 +91,19 @@ func (e *HttpRequestExecutor) Execute(ctx executors.Context) *executors.Executor
 		)
 	}
 
-	log.Println("Returning Task state Completed...")
+	logger.Info("Returning Task state Completed...")
 	return executors.NewExecutorResult(
 		executors.Output(m),
 		executors.Status(pb.TaskExecutionResponseMessage_TASK_STATE_COMPLETED),
 	)
 }
 
-func (e *HttpRequestExecutor) ExecuteWithParameters(p *HttpRequestParameters) (*HttpResponse, error) {
-	client, err := CreateHttpClient(p.timeout, p.certAuthentication)
+func (e *HttpRequestExecutor) ExecuteWithParameters(p *HttpRequestParameters) (resp *HttpResponse, err error) {
+	var span trace.Span
+	p.ctx, span = tracing.Start(p.ctx, "HttpRequestExecutor.ExecuteWithParameters", requestAttributes(p)...)
+	defer func() { endSpan(span, p, resp, err) }()
+
+	client, err := CreateHttpClient(p.ctx, p.timeout, p.certAuthentication)
 	if err != nil {
 		return nil, err
 	}
@@ -91,6 +121,38 @@ func (e *HttpRequestExecutor) ExecuteWithParameters(p *HttpRequestParameters) (*
 	return execute(client, p, authHeader)
 }
 
+// requestAttributes leaves out the URL of sensitive tasks, as it might contain secrets.
+func requestAttributes(p *HttpRequestParameters) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{attribute.String("http.request.method", p.method)}
+	if !p.sensitive {
+		attrs = append(attrs, attribute.String("url.full", p.url))
+	}
+	return attrs
+}
+
+// urlLogValues leaves out the URL of sensitive tasks from the logs, as requestAttributes does from the traces.
+func urlLogValues(url string, sensitive bool) []any {
+	if sensitive {
+		return nil
+	}
+	return []any{"url", url}
+}
+
+func endSpan(span trace.Span, p *HttpRequestParameters, resp *HttpResponse, err error) {
+	if resp != nil {
+		span.SetAttributes(attribute.String("http.response.status_code", resp.StatusCode))
+	}
+	tracing.EndWithError(span, spanError(err, p.sensitive))
+}
+
+// spanError leaves out the details of the errors of sensitive tasks, e.g. URLs or response bodies.
+func spanError(err error, sensitive bool) error {
+	if err != nil && sensitive {
+		return errors.New("HTTP request failed")
+	}
+	return err
+}
+
 func obtainCsrf(p *HttpRequestParameters, authHeader string) error {
 	fetcher := NewCsrfTokenFetcher(p, authHeader)
 	token, err := fetcher.Fetch()
@@ -107,56 +169,64 @@ func obtainCsrf(p *HttpRequestParameters, authHeader string) error {
 		)
 	}
 
-	p.headers[csrfTokenHeaders[0]] = token
+	p.headers[csrfTokenHeaders[0]] = []string{token}
 	return nil
 }
 
 func execute(c *http.Client, p *HttpRequestParameters, authHeader string) (*HttpResponse, error) {
-	req, timeCh, err := createRequest(p.method, p.url, p.headers, p.body, authHeader)
+	req, timeCh, err := createRequest(p.ctx, p.method, p.url, p.headers, p.body, authHeader, p.sensitive)
 	if err != nil {
 		return nil, executors.NewNonRetryableError("could not create http request: %v", err).WithCause(err)
 	}
 
-	log.Printf("HTTP Client: executing request %s %s...\n", p.method, p.url)
+	logger := log.FromContext(p.ctx)
+	logger.Info("HTTP Client: executing request", append([]any{"method", p.method}, urlLogValues(p.url, p.sensitive)...)...)
 	resp, err := c.Do(req)
+	if err != nil && p.ctx.Err() != nil {
+		// a deadline of the context is reported as a timeout as well, still the request has been aborted
+		logger.Info("HTTP Client: request cancelled", "reason", p.ctx.Err().Error())
+		return nil, executors.NewRetryableError("HTTP request cancelled: %v\nURL: %s\nMethod: %s", p.ctx.Err(), req.URL, req.Method).WithCause(err)
+	}
+
 	if requestTimedOut(err) {
-		log.Println("HTTP Client: request timed out after", c.Timeout, "seconds")
+		logger.Info("HTTP Client: request timed out", "timeout", c.Timeout.String())
 		if p.succeedOnTimeout {
-			log.Println("HTTP Client: SucceedOnTimeout has been configured. Returning successful response...")
-			return newTimedOutHttpResponse(req, resp)
+			logger.Info("HTTP Client: SucceedOnTimeout has been configured. Returning successful response...")
+			return newTimedOutHttpResponse(req, resp, p.taskVersion)
 		}
 
 		return nil, executors.NewRetryableError("HTTP request failed: %s\nURL: %s\nMethod: %s\nStatus: -1%s", err, req.URL, req.Method, resolveBodyAppendix("-1", "", p))
 	}
 
 	if err != nil {
-		log.Println("HTTP Client: error occurred while executing request:", err)
+		logger.Error(err, "HTTP Client: error occurred while executing request")
 		return nil, executors.NewNonRetryableError("HTTP request failed: %s\nURL: %s\nMethod: %s\nStatus: -1%s", err, req.URL, req.Method, resolveBodyAppendix("-1", "", p))
 	}
 	defer resp.Body.Close()
 
-	log.Println("HTTP Client: received response:", resp.Status)
+	logger.Info("HTTP Client: received response", "status", resp.Status)
+	metrics.HttpResponsesTotal.WithLabelValues(strconv.Itoa(resp.StatusCode)).Inc()
 
-	log.Println("HTTP Client: reading response body...")
+	logger.V(1).Info("HTTP Client: reading response body...")
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		log.Println("HTTP Client: error reading response body:", err)
+		logger.Error(err, "HTTP Client: error reading response body")
 		return nil, executors.NewNonRetryableError("HTTP request failed: %s\nURL: %s\nMethod: %s\nStatus: -1%s", err, req.URL, req.Method, resolveBodyAppendix("-1", "", p))
 	}
 
-	log.Println("HTTP Client: building response object...")
+	logger.V(1).Info("HTTP Client: building response object...")
 	r, err := NewHttpResponse(
 		Url(req.URL.String()),
 		Method(req.Method),
 		Content(string(body)),
-		Headers(resp.Header),
+		headersOfVersion(resp.Header, p.taskVersion),
 		StatusCode(resp.StatusCode),
 		ResponseBodyTransformer(p.responseBodyTransformer),
 		IsSuccessfulBasedOnSuccessResponseCodes(resp.StatusCode, p.successResponseCodes),
 		Time(<-timeCh),
 	)
 	if err != nil {
-		log.Println("HTTP Client: could not build response object:", err)
+		logger.Error(err, "HTTP Client: could not build response object")
 		return nil, executors.NewNonRetryableError("HTTP request failed: %s\nURL: %s\nMethod: %s\nStatus: -1%s", err, req.URL, req.Method, resolveBodyAppendix("-1", "", p))
 	}
 	return r, nil
@@ -167,16 +237,19 @@ func requestTimedOut(err error) bool {
 	return errors.As(err, &e) && e.Timeout()
 }
 
-func createRequest(method string, url string, headers map[string]string, body, authHeader string) (*http.Request, <-chan int64, error) {
-	log.Println("HTTP Client: creating request:", method, url)
+func createRequest(ctx context.Context, method string, url string, headers map[string][]string, body, authHeader string,
+	sensitive bool) (*http.Request, <-chan int64, error) {
+	logger := log.FromContext(ctx)
+	logger.V(1).Info("HTTP Client: creating request", append([]any{"method", method}, urlLogValues(url, sensitive)...)...)
 	timeCh := make(chan int64, 1)
 
-	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(body))
 	if err != nil {
-		log.Println("HTTP Client: error creating request:", err)
+		logger.Error(err, "HTTP Client: error creating request")
 		return nil, nil, err
 	}
 	addHeaders(req, headers, authHeader)
+	tracing.InjectHeaders(ctx, req.Header)
 
 	var start time.Time
 	trace := &httptrace.ClientTrace{
@@ -192,9 +265,11 @@ func createRequest(method string, url string, headers map[string]string, body, a
 	return req.WithContext(traceCtx), timeCh, nil
 }
 
-func addHeaders(req *http.Request, headers map[string]string, authHeader string) {
-	for k, v := range headers {
-		req.Header.Add(k, v)
+func addHeaders(req *http.Request, headers map[string][]string, authHeader string) {
+	for k, vs := range headers {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
 	}
 
 	if authHeader != "" {
@@ -1,6 +1,10 @@
 package http
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+
 	"github.com/SAP/remote-work-processor/internal/executors"
 	"github.com/SAP/remote-work-processor/internal/executors/http/tls"
 	"github.com/SAP/remote-work-processor/internal/functional"
@@ -29,6 +33,13 @@ const (
 	OMIT_BODY_IN_ERROR_MESSAGE string = "omitBodyInErrorMessage"
 )
 
+// Versions of the HTTP task input contract. Version 2 allows header values to be lists, in both the headers
+// input and the headers of the response, instead of joining the values of repeated headers.
+const (
+	taskVersionSingleValueHeaders int32 = 1
+	taskVersionMultiValueHeaders  int32 = 2
+)
+
 var defaultSuccessResponseCodes = []string{"2xx"}
 
 type HttpRequestParameters struct {
@@ -40,7 +51,7 @@ type HttpRequestParameters struct {
 	clientSecret            string
 	refreshToken            string
 	responseBodyTransformer string
-	headers                 map[string]string
+	headers                 map[string][]string
 	body                    string
 	user                    string
 	password                string
@@ -50,6 +61,9 @@ type HttpRequestParameters struct {
 	certAuthentication      *tls.CertificateAuthentication
 	authorizationHeader     string
 	omitBodyInErrorMessage  bool
+	taskVersion             int32
+	sensitive               bool
+	ctx                     context.Context
 
 	store map[string]string
 }
@@ -57,15 +71,17 @@ type HttpRequestParameters struct {
 func NewHttpRequestParametersFromContext(ctx executors.Context) (*HttpRequestParameters, error) {
 	method, err := ctx.GetRequiredString(METHOD)
 	if err != nil {
-		return nil, nonRetryableError(err)
+		return nil, executors.NonRetryable(err)
 	}
 
 	url, err := ctx.GetRequiredString(URL)
 	if err != nil {
-		return nil, nonRetryableError(err)
+		return nil, executors.NonRetryable(err)
 	}
 
 	opts := []functional.OptionWithError[HttpRequestParameters]{
+		withTaskVersionFromContext(ctx),
+		WithContext(ctx.GetContext()),
 		withTokenUrlFromContext(ctx),
 		withCsrfUrlFromContext(ctx),
 		withClientIdFromContext(ctx),
@@ -82,6 +98,7 @@ func NewHttpRequestParametersFromContext(ctx executors.Context) (*HttpRequestPar
 		withCertAuthenticationFromContext(ctx),
 		withAuthorizationHeaderFromContext(ctx),
 		withOmitBodyInErrorMessageFromContext(ctx),
+		withSensitiveFromContext(ctx),
 		withStoreFromContext(ctx),
 	}
 	return NewHttpRequestParameters(method, url, opts...)
@@ -89,8 +106,11 @@ func NewHttpRequestParametersFromContext(ctx executors.Context) (*HttpRequestPar
 
 func NewHttpRequestParameters(method, url string, opts ...functional.OptionWithError[HttpRequestParameters]) (*HttpRequestParameters, error) {
 	p := &HttpRequestParameters{
-		method: method,
-		url:    url,
+		method:      method,
+		url:         url,
+		headers:     make(map[string][]string),
+		taskVersion: taskVersionSingleValueHeaders,
+		ctx:         context.Background(),
 	}
 
 	for _, opt := range opts {
@@ -137,10 +157,31 @@ func (p HttpRequestParameters) GetOmitBodyInErrorMessage() bool {
 	return p.omitBodyInErrorMessage
 }
 
+func (p HttpRequestParameters) GetContext() context.Context {
+	return p.ctx
+}
+
+func (p HttpRequestParameters) IsSensitive() bool {
+	return p.sensitive
+}
+
+func (p HttpRequestParameters) GetTaskVersion() int32 {
+	return p.taskVersion
+}
+
 func (p HttpRequestParameters) GetCertificateAuthentication() *tls.CertificateAuthentication {
 	return p.certAuthentication
 }
 
+// WithContext sets the context aborting the request, along with the requests for tokens it depends on.
+func WithContext(ctx context.Context) functional.OptionWithError[HttpRequestParameters] {
+	return func(params *HttpRequestParameters) error {
+		params.ctx = ctx
+
+		return nil
+	}
+}
+
 func WithTokenUrl(u string) functional.OptionWithError[HttpRequestParameters] {
 	return func(params *HttpRequestParameters) error {
 		params.tokenUrl = u
@@ -182,6 +223,17 @@ func WithRefreshToken(rt string) functional.OptionWithError[HttpRequestParameter
 }
 
 func WithHeaders(h map[string]string) functional.OptionWithError[HttpRequestParameters] {
+	return func(params *HttpRequestParameters) error {
+		params.headers = make(map[string][]string, len(h))
+		for k, v := range h {
+			params.headers[k] = []string{v}
+		}
+
+		return nil
+	}
+}
+
+func WithMultiValueHeaders(h map[string][]string) functional.OptionWithError[HttpRequestParameters] {
 	return func(params *HttpRequestParameters) error {
 		params.headers = h
 
@@ -261,6 +313,16 @@ func withOmitBodyInErrorMessage(s bool) functional.OptionWithError[HttpRequestPa
 	}
 }
 
+// withSensitivity passes the sensitivity of a task on to the requests made on its behalf, e.g. for fetching tokens.
+func withSensitivity(sensitive bool, omitBodyInErrorMessage bool) functional.OptionWithError[HttpRequestParameters] {
+	return func(params *HttpRequestParameters) error {
+		params.sensitive = sensitive
+		params.omitBodyInErrorMessage = sensitive || omitBodyInErrorMessage
+
+		return nil
+	}
+}
+
 func withTokenUrlFromContext(ctx executors.Context) functional.OptionWithError[HttpRequestParameters] {
 	return func(params *HttpRequestParameters) error {
 		u := ctx.GetString(TOKEN_URL)
@@ -315,15 +377,30 @@ func withResponseBodyTransformerFromContext(ctx executors.Context) functional.Op
 	}
 }
 
+func withTaskVersionFromContext(ctx executors.Context) functional.OptionWithError[HttpRequestParameters] {
+	return func(params *HttpRequestParameters) error {
+		params.taskVersion = ctx.GetTaskVersion()
+		return nil
+	}
+}
+
 func withHeadersFromContext(ctx executors.Context) functional.OptionWithError[HttpRequestParameters] {
 	return func(params *HttpRequestParameters) error {
+		if params.taskVersion >= taskVersionMultiValueHeaders {
+			h, err := parseMultiValueHeaders(ctx.GetString(HEADERS))
+			if err != nil {
+				return executors.NonRetryable(err)
+			}
+
+			return WithMultiValueHeaders(h)(params)
+		}
+
 		h, err := ctx.GetMap(HEADERS)
 		if err != nil {
-			return nonRetryableError(err)
+			return executors.NonRetryable(err)
 		}
 
-		params.headers = h
-		return nil
+		return WithHeaders(h)(params)
 	}
 }
 
@@ -358,7 +435,7 @@ func withTimeoutFromContext(ctx executors.Context) functional.OptionWithError[Ht
 	return func(params *HttpRequestParameters) error {
 		timeout, err := ctx.GetNumber(TIMEOUT)
 		if err != nil {
-			return nonRetryableError(err)
+			return executors.NonRetryable(err)
 		}
 
 		params.timeout = timeout
@@ -370,7 +447,7 @@ func withSuccessResponseCodesFromContext(ctx executors.Context) functional.Optio
 	return func(params *HttpRequestParameters) error {
 		src, err := ctx.GetList(SUCCESS_RESPONSE_CODES)
 		if err != nil {
-			return nonRetryableError(err)
+			return executors.NonRetryable(err)
 		}
 
 		if len(src) == 0 {
@@ -386,7 +463,7 @@ func withSucceedOnTimeoutFromContext(ctx executors.Context) functional.OptionWit
 	return func(params *HttpRequestParameters) error {
 		s, err := ctx.GetBoolean(SUCCEED_ON_TIMEOUT)
 		if err != nil {
-			return nonRetryableError(err)
+			return executors.NonRetryable(err)
 		}
 
 		params.succeedOnTimeout = s
@@ -410,7 +487,7 @@ func withCertAuthenticationFromContext(ctx executors.Context) functional.OptionW
 
 		trustAnyCert, err := ctx.GetBoolean(TRUST_ANY_CERT)
 		if err != nil {
-			return nonRetryableError(err)
+			return executors.NonRetryable(err)
 		}
 		opts = append(opts, tls.TrustAnyCertificate(trustAnyCert))
 
@@ -429,6 +506,18 @@ func withAuthorizationHeaderFromContext(ctx executors.Context) functional.Option
 	}
 }
 
+// withSensitiveFromContext must come after withOmitBodyInErrorMessageFromContext, as the response bodies of
+// sensitive tasks are always omitted from the error messages.
+func withSensitiveFromContext(ctx executors.Context) functional.OptionWithError[HttpRequestParameters] {
+	return func(params *HttpRequestParameters) error {
+		if ctx.IsSensitive() {
+			params.sensitive = true
+			params.omitBodyInErrorMessage = true
+		}
+		return nil
+	}
+}
+
 func withStoreFromContext(ctx executors.Context) functional.OptionWithError[HttpRequestParameters] {
 	return func(params *HttpRequestParameters) error {
 		params.store = ctx.GetStore()
@@ -440,7 +529,7 @@ func withOmitBodyInErrorMessageFromContext(ctx executors.Context) functional.Opt
 	return func(params *HttpRequestParameters) error {
 		s, err := ctx.GetBoolean(OMIT_BODY_IN_ERROR_MESSAGE)
 		if err != nil {
-			return nonRetryableError(err)
+			return executors.NonRetryable(err)
 		}
 
 		params.omitBodyInErrorMessage = s
@@ -448,6 +537,30 @@ func withOmitBodyInErrorMessageFromContext(ctx executors.Context) functional.Opt
 	}
 }
 
-func nonRetryableError(cause error) error {
-	return executors.NewNonRetryableError("%s", cause.Error()).WithCause(cause)
+// parseMultiValueHeaders parses a JSON object whose values are either a single string or a list of strings.
+func parseMultiValueHeaders(s string) (map[string][]string, error) {
+	headers := make(map[string][]string)
+	if s == "" {
+		return headers, nil
+	}
+
+	raw := make(map[string]json.RawMessage)
+	if err := json.Unmarshal([]byte(s), &raw); err != nil {
+		return nil, err
+	}
+
+	for k, v := range raw {
+		var single string
+		if err := json.Unmarshal(v, &single); err == nil {
+			headers[k] = []string{single}
+			continue
+		}
+
+		var values []string
+		if err := json.Unmarshal(v, &values); err != nil {
+			return nil, fmt.Errorf("value of header %q must be a string or a list of strings", k)
+		}
+		headers[k] = values
+	}
+	return headers, nil
 }
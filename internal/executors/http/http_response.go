@@ -14,21 +14,26 @@ import (
 
 type HttpHeaders map[string]string
 
-type HttpResponse struct {
-	Url                     string      `json:"url"`
-	Method                  string      `json:"method"`
-	Content                 string      `json:"body"`
-	Headers                 HttpHeaders `json:"headers"`
-	StatusCode              string      `json:"status"`
-	SizeInBytes             uint64      `json:"size"`
-	Time                    int64       `json:"time"`
-	ResponseBodyTransformer string      `json:"responseBodyTransformer"`
+type MultiValueHttpHeaders map[string][]string
 
+type HttpResponse struct {
+	Url                     string       `json:"url"`
+	Method                  string       `json:"method"`
+	Content                 string       `json:"body"`
+	Headers                 fmt.Stringer `json:"headers"`
+	StatusCode              string       `json:"status"`
+	SizeInBytes             uint64       `json:"size"`
+	Time                    int64        `json:"time"`
+	ResponseBodyTransformer string       `json:"responseBodyTransformer"`
+	TransformedBody         string       `json:"transformedBody"`
+
+	header     http.Header
 	successful bool
 }
 
 func NewHttpResponse(opts ...functional.OptionWithError[HttpResponse]) (*HttpResponse, error) {
 	r := &HttpResponse{
+		Headers:    HttpHeaders(nil),
 		successful: true,
 	}
 
@@ -42,7 +47,7 @@ func NewHttpResponse(opts ...functional.OptionWithError[HttpResponse]) (*HttpRes
 	return r, nil
 }
 
-func newTimedOutHttpResponse(req *http.Request, resp *http.Response) (*HttpResponse, error) {
+func newTimedOutHttpResponse(req *http.Request, resp *http.Response, taskVersion int32) (*HttpResponse, error) {
 	opts := []functional.OptionWithError[HttpResponse]{
 		Url(req.URL.String()),
 		Method(req.Method),
@@ -50,7 +55,7 @@ func newTimedOutHttpResponse(req *http.Request, resp *http.Response) (*HttpRespo
 	}
 
 	if resp != nil {
-		opts = append(opts, Headers(req.Header))
+		opts = append(opts, headersOfVersion(req.Header, taskVersion))
 	}
 
 	return NewHttpResponse(opts...)
@@ -90,10 +95,26 @@ func Headers(headers http.Header) functional.OptionWithError[HttpResponse] {
 		}
 
 		hr.Headers = h
+		hr.header = headers
+		return nil
+	}
+}
+
+func MultiValueHeaders(headers http.Header) functional.OptionWithError[HttpResponse] {
+	return func(hr *HttpResponse) error {
+		hr.Headers = MultiValueHttpHeaders(headers)
+		hr.header = headers
 		return nil
 	}
 }
 
+func headersOfVersion(headers http.Header, taskVersion int32) functional.OptionWithError[HttpResponse] {
+	if taskVersion >= taskVersionMultiValueHeaders {
+		return MultiValueHeaders(headers)
+	}
+	return Headers(headers)
+}
+
 func StatusCode(code int) functional.OptionWithError[HttpResponse] {
 	return func(hr *HttpResponse) error {
 		hr.StatusCode = strconv.Itoa(code)
@@ -192,3 +213,8 @@ func (h HttpHeaders) String() string {
 	bytes, _ := json.Marshal(h)
 	return string(bytes)
 }
+
+func (h MultiValueHttpHeaders) String() string {
+	bytes, _ := json.Marshal(h)
+	return string(bytes)
+}
@@ -1,9 +1,10 @@
 package http
 
 import (
+	"context"
 	"fmt"
 	"github.com/SAP/remote-work-processor/internal/utils"
-	"log"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const PASSCODE string = "passcode"
@@ -11,12 +12,14 @@ const PASSCODE string = "passcode"
 type iasAuthorizationHeader struct {
 	user    string
 	fetcher TokenFetcher
+	ctx     context.Context
 }
 
-func NewIasAuthorizationHeader(tokenUrl, user, clientCert string) AuthorizationHeaderGenerator {
+func NewIasAuthorizationHeader(p *HttpRequestParameters) AuthorizationHeaderGenerator {
 	return &iasAuthorizationHeader{
-		user:    user,
-		fetcher: NewIasTokenFetcher(tokenUrl, user, clientCert),
+		user:    p.GetUser(),
+		fetcher: NewIasTokenFetcher(p),
+		ctx:     p.GetContext(),
 	}
 }
 
@@ -26,18 +29,19 @@ func (h *iasAuthorizationHeader) Generate() (string, error) {
 		return "", fmt.Errorf("failed to fetch IAS token: %v", err)
 	}
 
+	logger := log.FromContext(h.ctx)
 	parsed := make(map[string]any)
 	if err = utils.FromJson(raw, &parsed); err != nil {
-		log.Println("IAS authorization header: failed to parse IAS token response:", err)
+		logger.Error(err, "IAS authorization header: failed to parse IAS token response")
 		return "", fmt.Errorf("failed to parse IAS token response: %v", err)
 	}
 
 	pass, prs := parsed[PASSCODE]
 	if !prs {
-		log.Println("IAS authorization header: passcode does not exist in the HTTP response")
+		logger.Info("IAS authorization header: passcode does not exist in the HTTP response")
 		return "", fmt.Errorf("passcode does not exist in the HTTP response")
 	}
 
-	log.Println("IAS authorization header: using basic auth with passcode...")
-	return NewBasicAuthorizationHeader(h.user, pass.(string)).Generate()
+	logger.V(1).Info("IAS authorization header: using basic auth with passcode...")
+	return NewBasicAuthorizationHeader(h.ctx, h.user, pass.(string)).Generate()
 }
@@ -1,10 +1,13 @@
 package http
 
 import (
-	"log"
+	"context"
 	"net/http"
 
 	"github.com/SAP/remote-work-processor/internal/executors/http/tls"
+	"github.com/SAP/remote-work-processor/internal/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 type iasTokenFetcher struct {
@@ -12,24 +15,35 @@ type iasTokenFetcher struct {
 	tokenUrl   string
 	user       string
 	clientCert string
+	sensitive  bool
+	omitBody   bool
+	ctx        context.Context
 }
 
-func NewIasTokenFetcher(tokenUrl, user, clientCert string) TokenFetcher {
+func NewIasTokenFetcher(p *HttpRequestParameters) TokenFetcher {
 	return &iasTokenFetcher{
 		HttpExecutor: NewDefaultHttpRequestExecutor(),
-		tokenUrl:     tokenUrl,
-		user:         user,
-		clientCert:   clientCert,
+		tokenUrl:     p.GetTokenUrl(),
+		user:         p.GetUser(),
+		clientCert:   p.GetCertificateAuthentication().GetClientCertificate(),
+		sensitive:    p.IsSensitive(),
+		omitBody:     p.GetOmitBodyInErrorMessage(),
+		ctx:          p.GetContext(),
 	}
 }
 
-func (f *iasTokenFetcher) Fetch() (string, error) {
-	log.Println("IAS token fetcher: fetching new token from:", f.tokenUrl)
+func (f *iasTokenFetcher) Fetch() (token string, err error) {
+	var span trace.Span
+	f.ctx, span = tracing.Start(f.ctx, "iasTokenFetcher.Fetch")
+	defer func() { tracing.EndWithError(span, spanError(err, f.sensitive)) }()
+
+	logger := log.FromContext(f.ctx)
+	logger.Info("IAS token fetcher: fetching new token", urlLogValues(f.tokenUrl, f.sensitive)...)
 	params, _ := f.createRequestParameters()
 
 	resp, err := f.HttpExecutor.ExecuteWithParameters(params)
 	if err != nil {
-		log.Println("IAS token fetcher: failed to fetch token:", err)
+		logger.Error(err, "IAS token fetcher: failed to fetch token")
 		return "", err
 	}
 
@@ -41,5 +55,5 @@ func (f *iasTokenFetcher) createRequestParameters() (*HttpRequestParameters, err
 		tls.NewCertAuthentication(
 			tls.WithClientCertificate(f.clientCert),
 		),
-	))
+	), WithContext(f.ctx), withSensitivity(f.sensitive, f.omitBody))
 }
@@ -1,12 +1,13 @@
 package http
 
 import (
+	"context"
 	"fmt"
 	"github.com/SAP/remote-work-processor/internal/utils"
-	"log"
 	"time"
 
 	"github.com/SAP/remote-work-processor/internal/executors/http/tls"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 type OAuthorizationHeaderOption func(*oAuthorizationHeaderGenerator)
@@ -17,7 +18,10 @@ type oAuthorizationHeaderGenerator struct {
 	authHeader         string
 	cachingKey         string
 	requestStore       map[string]string
+	sensitive          bool
+	omitBody           bool
 	fetcher            TokenFetcher
+	ctx                context.Context
 }
 
 type cachedToken struct {
@@ -30,6 +34,7 @@ func NewOAuthorizationHeaderGenerator(tokenType TokenType, tokenUrl string, exec
 	h := &oAuthorizationHeaderGenerator{
 		tokenType:    tokenType,
 		requestStore: make(map[string]string),
+		ctx:          context.Background(),
 	}
 
 	for _, opt := range opts {
@@ -42,6 +47,8 @@ func NewOAuthorizationHeaderGenerator(tokenType TokenType, tokenUrl string, exec
 		withRequestBody(requestBody),
 		withCertificateAuthentication(h.certAuthentication),
 		withAuthHeader(h.authHeader),
+		withFetchContext(h.ctx),
+		withFetchSensitivity(h.sensitive, h.omitBody),
 	)
 
 	return h
@@ -59,6 +66,20 @@ func WithAuthenticationHeader(header string) OAuthorizationHeaderOption {
 	}
 }
 
+func WithFetchContext(ctx context.Context) OAuthorizationHeaderOption {
+	return func(h *oAuthorizationHeaderGenerator) {
+		h.ctx = ctx
+	}
+}
+
+// WithFetchSensitivity passes the sensitivity of the task on to the token request.
+func WithFetchSensitivity(sensitive bool, omitBodyInErrorMessage bool) OAuthorizationHeaderOption {
+	return func(h *oAuthorizationHeaderGenerator) {
+		h.sensitive = sensitive
+		h.omitBody = omitBodyInErrorMessage
+	}
+}
+
 func WithCachingKey(cacheKey string) OAuthorizationHeaderOption {
 	return func(h *oAuthorizationHeaderGenerator) {
 		h.cachingKey = cacheKey
@@ -81,12 +102,13 @@ func (h *oAuthorizationHeaderGenerator) Generate() (string, error) {
 }
 
 func (h *oAuthorizationHeaderGenerator) GenerateWithCacheAside() (string, error) {
-	log.Println("OAuth token header: checking for token in cache...")
+	logger := log.FromContext(h.ctx)
+	logger.V(1).Info("OAuth token header: checking for token in cache...")
 	var cached cachedToken
 	if cachedValue, inCache := h.requestStore[h.cachingKey]; inCache {
-		log.Println("OAuth token header: token found in cache")
+		logger.V(1).Info("OAuth token header: token found in cache")
 		if err := utils.FromJson(cachedValue, &cached); err != nil {
-			log.Println("OAuth token header: error decoding cached token:", err)
+			logger.Error(err, "OAuth token header: error decoding cached token")
 			return "", fmt.Errorf("failed to deserialize cached OAuth token: %v", err)
 		}
 	} else {
@@ -94,7 +116,7 @@ func (h *oAuthorizationHeaderGenerator) GenerateWithCacheAside() (string, error)
 	}
 
 	if h.tokenAboutToExpire(cached) {
-		log.Println("OAuth token header: token is close to expiry. regenerating...")
+		logger.Info("OAuth token header: token is close to expiry. regenerating...")
 		newToken, err := h.fetchToken()
 		if err != nil {
 			return "", err
@@ -107,11 +129,11 @@ func (h *oAuthorizationHeaderGenerator) GenerateWithCacheAside() (string, error)
 
 		newCachedToken, err := utils.ToJson(cached)
 		if err != nil {
-			log.Println("OAuth token header: failed to serialize token:", err)
+			logger.Error(err, "OAuth token header: failed to serialize token")
 			return "", fmt.Errorf("failed to serialize OAuth token: %v", err)
 		}
 
-		log.Println("OAuth token header: setting new token in cache")
+		logger.V(1).Info("OAuth token header: setting new token in cache")
 		h.requestStore[h.cachingKey] = newCachedToken
 	}
 
@@ -126,14 +148,15 @@ func (h *oAuthorizationHeaderGenerator) tokenAboutToExpire(token cachedToken) bo
 func (h *oAuthorizationHeaderGenerator) fetchToken() (*OAuthToken, error) {
 	rawToken, err := h.fetcher.Fetch()
 	if err != nil {
-		log.Println("OAuth token header: failed to fetch token:", err)
+		log.FromContext(h.ctx).Error(err, "OAuth token header: failed to fetch token")
 		return nil, fmt.Errorf("failed to fetch OAuth token: %v", err)
 	}
 	return NewOAuthToken(rawToken)
 }
 
 func (h *oAuthorizationHeaderGenerator) formatToken(oAuthToken *OAuthToken) (string, error) {
-	log.Println("OAuth token header: formatting token...")
+	logger := log.FromContext(h.ctx)
+	logger.V(1).Info("OAuth token header: formatting token...")
 	var token string
 	switch h.tokenType {
 	case TokenType_ACCESS:
@@ -141,7 +164,7 @@ func (h *oAuthorizationHeaderGenerator) formatToken(oAuthToken *OAuthToken) (str
 	case TokenType_ID:
 		token = oAuthToken.IdToken
 	default:
-		log.Println("OAuth token header: invalid token type:", h.tokenType)
+		logger.Info("OAuth token header: invalid token type", "token_type", h.tokenType)
 		return "", NewIllegalTokenTypeError(h.tokenType)
 	}
 
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
@@ -67,9 +68,11 @@ func passwordGrantGenerator(p *HttpRequestParameters) CacheableAuthorizationHead
 		tokenUrl,
 		NewDefaultHttpRequestExecutor(),
 		body,
-		WithAuthenticationHeader(generateBasicAuthorizationHeader(clientId, clientSecret)),
+		WithAuthenticationHeader(generateBasicAuthorizationHeader(p.ctx, clientId, clientSecret)),
 		WithCachingKey(generateCachingKey(tokenUrl, clientId, clientSecret, body)),
-		WithCacheStore(p.store))
+		WithCacheStore(p.store),
+		WithFetchContext(p.ctx),
+		sensitivityOf(p))
 }
 
 func passwordGrantWithClientCertificateGenerator(p *HttpRequestParameters) CacheableAuthorizationHeaderGenerator {
@@ -84,7 +87,9 @@ func passwordGrantWithClientCertificateGenerator(p *HttpRequestParameters) Cache
 		body,
 		UseCertificateAuthentication(p.GetCertificateAuthentication()),
 		WithCachingKey(generateCachingKey(tokenUrl, clientId, "", body)),
-		WithCacheStore(p.store))
+		WithCacheStore(p.store),
+		WithFetchContext(p.ctx),
+		sensitivityOf(p))
 }
 
 func clientCredentialsGenerator(p *HttpRequestParameters, clientId string, clientSecret string) CacheableAuthorizationHeaderGenerator {
@@ -94,7 +99,7 @@ func clientCredentialsGenerator(p *HttpRequestParameters, clientId string, clien
 	var opt OAuthorizationHeaderOption
 
 	if clientId != "" && p.GetCertificateAuthentication().GetClientCertificate() == "" {
-		opt = WithAuthenticationHeader(generateBasicAuthorizationHeader(clientId, clientSecret))
+		opt = WithAuthenticationHeader(generateBasicAuthorizationHeader(p.ctx, clientId, clientSecret))
 	} else {
 		opt = UseCertificateAuthentication(p.GetCertificateAuthentication())
 	}
@@ -105,7 +110,9 @@ func clientCredentialsGenerator(p *HttpRequestParameters, clientId string, clien
 		body,
 		opt,
 		WithCachingKey(generateCachingKey(tokenUrl, clientId, clientSecret, body)),
-		WithCacheStore(p.store))
+		WithCacheStore(p.store),
+		WithFetchContext(p.ctx),
+		sensitivityOf(p))
 }
 
 func refreshTokenGenerator(p *HttpRequestParameters) CacheableAuthorizationHeaderGenerator {
@@ -115,14 +122,15 @@ func refreshTokenGenerator(p *HttpRequestParameters) CacheableAuthorizationHeade
 	refreshToken := p.GetRefreshToken()
 
 	if p.GetCertificateAuthentication().GetClientCertificate() == "" {
-		return refreshTokenGrant(tokenUrl, clientId, clientSecret, refreshToken, p.store)
+		return refreshTokenGrant(p.ctx, tokenUrl, clientId, clientSecret, refreshToken, p.store, sensitivityOf(p))
 	} else {
-		return refreshTokenGrantWithClientCert(tokenUrl, clientId, refreshToken, p.GetCertificateAuthentication(), p.store)
+		return refreshTokenGrantWithClientCert(p.ctx, tokenUrl, clientId, refreshToken, p.GetCertificateAuthentication(), p.store,
+			sensitivityOf(p))
 	}
 }
 
-func refreshTokenGrantWithClientCert(tokenUrl, clientId, refreshToken string, certAuthentication *tls.CertificateAuthentication,
-	store map[string]string) CacheableAuthorizationHeaderGenerator {
+func refreshTokenGrantWithClientCert(ctx context.Context, tokenUrl, clientId, refreshToken string, certAuthentication *tls.CertificateAuthentication,
+	store map[string]string, sensitivity OAuthorizationHeaderOption) CacheableAuthorizationHeaderGenerator {
 	body := fmt.Sprintf(REFRESH_TOKEN_FORMAT_WITH_CERT, urlEncoded(clientId), urlEncoded(refreshToken))
 
 	return NewOAuthorizationHeaderGenerator(TokenType_ACCESS,
@@ -131,18 +139,23 @@ func refreshTokenGrantWithClientCert(tokenUrl, clientId, refreshToken string, ce
 		body,
 		UseCertificateAuthentication(certAuthentication),
 		WithCachingKey(generateCachingKey(tokenUrl, clientId, "", body)),
-		WithCacheStore(store))
+		WithCacheStore(store),
+		WithFetchContext(ctx),
+		sensitivity)
 }
 
-func refreshTokenGrant(tokenUrl, clientId, clientSecret, refreshToken string, store map[string]string) CacheableAuthorizationHeaderGenerator {
+func refreshTokenGrant(ctx context.Context, tokenUrl, clientId, clientSecret, refreshToken string, store map[string]string,
+	sensitivity OAuthorizationHeaderOption) CacheableAuthorizationHeaderGenerator {
 	body := fmt.Sprintf(REFRESH_TOKEN_FORMAT, urlEncoded(refreshToken))
 
 	var opts []OAuthorizationHeaderOption
 	if clientId != "" {
-		opts = append(opts, WithAuthenticationHeader(generateBasicAuthorizationHeader(clientId, clientSecret)))
+		opts = append(opts, WithAuthenticationHeader(generateBasicAuthorizationHeader(ctx, clientId, clientSecret)))
 	}
 	opts = append(opts, WithCachingKey(generateCachingKey(tokenUrl, clientId, clientSecret, body)),
-		WithCacheStore(store))
+		WithCacheStore(store),
+		WithFetchContext(ctx),
+		sensitivity)
 
 	return NewOAuthorizationHeaderGenerator(TokenType_ACCESS,
 		tokenUrl,
@@ -151,8 +164,12 @@ func refreshTokenGrant(tokenUrl, clientId, clientSecret, refreshToken string, st
 		opts...)
 }
 
-func generateBasicAuthorizationHeader(clientId string, clientSecret string) string {
-	header, _ := NewBasicAuthorizationHeader(clientId, clientSecret).Generate()
+func sensitivityOf(p *HttpRequestParameters) OAuthorizationHeaderOption {
+	return WithFetchSensitivity(p.IsSensitive(), p.GetOmitBodyInErrorMessage())
+}
+
+func generateBasicAuthorizationHeader(ctx context.Context, clientId string, clientSecret string) string {
+	header, _ := NewBasicAuthorizationHeader(ctx, clientId, clientSecret).Generate()
 	return header
 }
 
@@ -1,11 +1,14 @@
 package http
 
 import (
-	"log"
+	"context"
 	"net/http"
 
 	"github.com/SAP/remote-work-processor/internal/executors/http/tls"
 	"github.com/SAP/remote-work-processor/internal/functional"
+	"github.com/SAP/remote-work-processor/internal/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 type oAuthTokenFetcher struct {
@@ -14,10 +17,15 @@ type oAuthTokenFetcher struct {
 	body               string
 	authHeader         string
 	certAuthentication *tls.CertificateAuthentication
+	sensitive          bool
+	omitBody           bool
+	ctx                context.Context
 }
 
 func NewOAuthTokenFetcher(opts ...functional.Option[oAuthTokenFetcher]) TokenFetcher {
-	f := &oAuthTokenFetcher{}
+	f := &oAuthTokenFetcher{
+		ctx: context.Background(),
+	}
 
 	for _, opt := range opts {
 		opt(f)
@@ -26,6 +34,12 @@ func NewOAuthTokenFetcher(opts ...functional.Option[oAuthTokenFetcher]) TokenFet
 	return f
 }
 
+func withFetchContext(ctx context.Context) functional.Option[oAuthTokenFetcher] {
+	return func(f *oAuthTokenFetcher) {
+		f.ctx = ctx
+	}
+}
+
 func withExecutor(executor HttpExecutor) functional.Option[oAuthTokenFetcher] {
 	return func(f *oAuthTokenFetcher) {
 		f.HttpExecutor = executor
@@ -56,14 +70,26 @@ func withCertificateAuthentication(auth *tls.CertificateAuthentication) function
 	}
 }
 
-func (f *oAuthTokenFetcher) Fetch() (string, error) {
+func withFetchSensitivity(sensitive bool, omitBodyInErrorMessage bool) functional.Option[oAuthTokenFetcher] {
+	return func(f *oAuthTokenFetcher) {
+		f.sensitive = sensitive
+		f.omitBody = omitBodyInErrorMessage
+	}
+}
+
+func (f *oAuthTokenFetcher) Fetch() (token string, err error) {
+	var span trace.Span
+	f.ctx, span = tracing.Start(f.ctx, "oAuthTokenFetcher.Fetch")
+	defer func() { tracing.EndWithError(span, spanError(err, f.sensitive)) }()
+
 	params, _ := f.createRequestParameters()
 
-	log.Println("OAuth token fetcher: fetching new token from", f.tokenUrl)
+	logger := log.FromContext(f.ctx)
+	logger.Info("OAuth token fetcher: fetching new token", urlLogValues(f.tokenUrl, f.sensitive)...)
 	// TODO: TOTP should be handled here
 	req, err := f.HttpExecutor.ExecuteWithParameters(params)
 	if err != nil {
-		log.Println("OAuth token fetcher: failed to fetch token:", err)
+		logger.Error(err, "OAuth token fetcher: failed to fetch token")
 		return "", err
 	}
 
@@ -75,6 +101,8 @@ func (f *oAuthTokenFetcher) createRequestParameters() (*HttpRequestParameters, e
 		WithHeaders(ContentTypeUrlFormEncoded()),
 		WithBody(f.body),
 		WithAuthorizationHeader(f.authHeader),
+		WithContext(f.ctx),
+		withSensitivity(f.sensitive, f.omitBody),
 	}
 
 	if f.certAuthentication != nil {
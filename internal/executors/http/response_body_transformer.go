@@ -0,0 +1,69 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/SAP/remote-work-processor/internal/executors"
+	"github.com/itchyny/gojq"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// transformResponseBody runs the jq expression over the JSON response body.
+// A single string result is returned as is, any other single result is serialized as JSON
+// and multiple results are serialized as a JSON array. Evaluation errors may quote parts of the body,
+// hence they are reported without details when bodies are to be omitted from error messages.
+func transformResponseBody(ctx context.Context, transformer string, body string, omitBodyInErrorMessage bool) (string, error) {
+	log.FromContext(ctx).V(1).Info("HTTP Client: applying response body transformer...")
+	q, err := gojq.Parse(transformer)
+	if err != nil {
+		return "", executors.NewNonRetryableError("Failed to parse response body transformer %q: %v", transformer, err).WithCause(err)
+	}
+
+	code, err := gojq.Compile(q)
+	if err != nil {
+		return "", executors.NewNonRetryableError("Failed to compile response body transformer %q: %v", transformer, err).WithCause(err)
+	}
+
+	var input any
+	if err = json.Unmarshal([]byte(body), &input); err != nil {
+		return "", executors.NewNonRetryableError("Failed to apply response body transformer: response body is not a valid JSON: %v", err).WithCause(err)
+	}
+
+	var results []any
+	iter := code.Run(input)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			break
+		}
+
+		if err, isErr := v.(error); isErr {
+			if omitBodyInErrorMessage {
+				return "", executors.NewNonRetryableError("Failed to apply response body transformer %q", transformer).WithCause(err)
+			}
+			return "", executors.NewNonRetryableError("Failed to apply response body transformer %q: %v", transformer, err).WithCause(err)
+		}
+		results = append(results, v)
+	}
+
+	switch len(results) {
+	case 0:
+		return "", nil
+	case 1:
+		if s, isString := results[0].(string); isString {
+			return s, nil
+		}
+		return marshalTransformed(results[0])
+	default:
+		return marshalTransformed(results)
+	}
+}
+
+func marshalTransformed(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", executors.NewNonRetryableError("Failed to serialize transformed response body: %v", err).WithCause(err)
+	}
+	return string(b), nil
+}
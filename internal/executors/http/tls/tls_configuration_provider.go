@@ -1,6 +1,7 @@
 package tls
 
 import (
+	"context"
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/ed25519"
@@ -9,21 +10,25 @@ import (
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/pem"
-	"log"
 	"net/http"
 
 	"github.com/SAP/remote-work-processor/internal/executors"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 type ConfigurationProvider struct {
 	*CertificateAuthentication
 	certPool *x509.CertPool
+	logger   logr.Logger
 }
 
-func NewTLSConfigurationProvider(certAuth *CertificateAuthentication) *ConfigurationProvider {
+func NewTLSConfigurationProvider(ctx context.Context, certAuth *CertificateAuthentication) *ConfigurationProvider {
+	logger := log.FromContext(ctx)
 	return &ConfigurationProvider{
 		CertificateAuthentication: certAuth,
-		certPool:                  ensureCertificatePool(),
+		certPool:                  ensureCertificatePool(logger),
+		logger:                    logger,
 	}
 }
 
@@ -32,21 +37,21 @@ func (p *ConfigurationProvider) CreateTransport() (http.RoundTripper, error) {
 		TLSClientConfig: &tls.Config{},
 	}
 
-	log.Println("TLS transport: trust any certificate:", p.TrustAnyCertificate())
+	p.logger.V(1).Info("TLS transport: trust any certificate", "trust_any_certificate", p.TrustAnyCertificate())
 	t.TLSClientConfig.InsecureSkipVerify = p.TrustAnyCertificate()
 
 	if p.UseTrustedCertificates() {
-		log.Println("TLS transport: adding trusted certificates...")
+		p.logger.V(1).Info("TLS transport: adding trusted certificates...")
 		if err := p.trustCertificate(t, p.trustedCerts); err != nil {
-			log.Println("TLS transport: failed to add trusted certificate:", err)
+			p.logger.Error(err, "TLS transport: failed to add trusted certificate")
 			return nil, err
 		}
 	}
 
 	if p.UseClientCertificate() {
-		log.Println("TLS transport: adding client certificate...")
+		p.logger.V(1).Info("TLS transport: adding client certificate...")
 		if err := p.registerClientCertificate(t, p.clientCert); err != nil {
-			log.Println("TLS transport: failed to add client certificate:", err)
+			p.logger.Error(err, "TLS transport: failed to add client certificate")
 			return nil, err
 		}
 	}
@@ -55,9 +60,9 @@ func (p *ConfigurationProvider) CreateTransport() (http.RoundTripper, error) {
 }
 
 func (p *ConfigurationProvider) registerClientCertificate(tr *http.Transport, certs string) error {
-	certs = decodeIfBase64(certs)
+	certs = decodeIfBase64(p.logger, certs)
 
-	cert, err := parseCertificate([]byte(certs))
+	cert, err := parseCertificate(p.logger, []byte(certs))
 	if err != nil {
 		return err
 	}
@@ -66,8 +71,8 @@ func (p *ConfigurationProvider) registerClientCertificate(tr *http.Transport, ce
 	return nil
 }
 
-func parseCertificate(certWithKey []byte) (tls.Certificate, error) {
-	log.Println("TLS transport: parsing certificate...")
+func parseCertificate(logger logr.Logger, certWithKey []byte) (tls.Certificate, error) {
+	logger.V(1).Info("TLS transport: parsing certificate...")
 	cert := tls.Certificate{}
 	var err error
 
@@ -80,7 +85,7 @@ func parseCertificate(certWithKey []byte) (tls.Certificate, error) {
 		if block.Type == "CERTIFICATE" {
 			cert.Certificate = append(cert.Certificate, block.Bytes)
 		} else {
-			if cert.PrivateKey, err = parsePK(block.Bytes); err != nil {
+			if cert.PrivateKey, err = parsePK(logger, block.Bytes); err != nil {
 				return tls.Certificate{}, err
 			}
 		}
@@ -91,14 +96,14 @@ func parseCertificate(certWithKey []byte) (tls.Certificate, error) {
 	return cert, nil
 }
 
-func parsePK(block []byte) (crypto.PrivateKey, error) {
-	log.Println("TLS transport: parsing private key...")
+func parsePK(logger logr.Logger, block []byte) (crypto.PrivateKey, error) {
+	logger.V(1).Info("TLS transport: parsing private key...")
 	if pk, err := x509.ParsePKCS8PrivateKey(block); err == nil {
 		switch pk.(type) {
 		case *rsa.PrivateKey, *ecdsa.PrivateKey, *ed25519.PrivateKey:
 			return pk, nil
 		default:
-			log.Println("TLS transport: failed to parse private key: unrecognized private key format")
+			logger.Info("TLS transport: failed to parse private key: unrecognized private key format")
 			return nil, executors.NewNonRetryableError("Unrecognized private key format")
 		}
 	}
@@ -107,15 +112,15 @@ func parsePK(block []byte) (crypto.PrivateKey, error) {
 		return pk, nil
 	}
 
-	log.Println("TLS transport: failed to parse private key: unsupported algorithm")
+	logger.Info("TLS transport: failed to parse private key: unsupported algorithm")
 	return nil, executors.NewNonRetryableError("Failed to parse client private key")
 }
 
 func (p *ConfigurationProvider) trustCertificate(tr *http.Transport, certs string) error {
-	certs = decodeIfBase64(certs)
+	certs = decodeIfBase64(p.logger, certs)
 	ok := p.certPool.AppendCertsFromPEM([]byte(certs))
 	if !ok {
-		log.Println("TLS transport: failed to register certificate to certificate pool")
+		p.logger.Info("TLS transport: failed to register certificate to certificate pool")
 		return executors.NewNonRetryableError("Failed to register the trusted certificate")
 	}
 
@@ -123,20 +128,20 @@ func (p *ConfigurationProvider) trustCertificate(tr *http.Transport, certs strin
 	return nil
 }
 
-func decodeIfBase64(certs string) string {
-	log.Println("TLS transport: decoding certificates...")
+func decodeIfBase64(logger logr.Logger, certs string) string {
+	logger.V(1).Info("TLS transport: decoding certificates...")
 	decoded, err := base64.StdEncoding.DecodeString(certs)
 	if err != nil {
-		log.Println("TLS transport: certificates not in base64 format. Using raw certificate data")
+		logger.V(1).Info("TLS transport: certificates not in base64 format. Using raw certificate data")
 		return certs
 	}
 	return string(decoded)
 }
 
-func ensureCertificatePool() *x509.CertPool {
+func ensureCertificatePool(logger logr.Logger) *x509.CertPool {
 	pool, err := x509.SystemCertPool()
 	if err != nil {
-		log.Println("TLS transport: failed to get system certificate pool, a new one will be created")
+		logger.Info("TLS transport: failed to get system certificate pool, a new one will be created", "reason", err.Error())
 		pool = x509.NewCertPool()
 	}
 	return pool
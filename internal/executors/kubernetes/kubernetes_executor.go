@@ -0,0 +1,199 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	pb "github.com/SAP/remote-work-processor/build/proto/generated"
+	"github.com/SAP/remote-work-processor/internal/executors"
+	"github.com/SAP/remote-work-processor/internal/kubernetes/dynamic"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dyn "k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	OBJECT_KEY = "object"
+)
+
+type KubernetesApiRequestExecutor struct {
+	executors.Executor
+	client *dynamic.Client
+}
+
+func NewKubernetesApiRequestExecutor(client *dynamic.Client) *KubernetesApiRequestExecutor {
+	return &KubernetesApiRequestExecutor{
+		client: client,
+	}
+}
+
+func (e *KubernetesApiRequestExecutor) Execute(ctx executors.Context) *executors.ExecutorResult {
+	logger := log.FromContext(ctx.GetContext())
+	logger.Info("Executing KubernetesApiRequest command...")
+	params, err := NewKubernetesApiRequestParametersFromContext(ctx)
+	if err != nil {
+		logger.Error(err, "Could not create Kubernetes API request params: returning Task state Failed Non-Retryable Error")
+		return executors.NewExecutorResult(
+			executors.Status(pb.TaskExecutionResponseMessage_TASK_STATE_FAILED_NON_RETRYABLE),
+			executors.Error(err),
+		)
+	}
+
+	result, err := e.ExecuteWithParameters(params)
+	if err != nil {
+		var retryable *executors.RetryableError
+		if errors.As(err, &retryable) {
+			logger.Info("Returning Task state Failed Retryable Error...")
+			return executors.NewExecutorResult(
+				executors.Status(pb.TaskExecutionResponseMessage_TASK_STATE_FAILED_RETRYABLE),
+				executors.Error(err),
+			)
+		}
+		logger.Info("Returning Task state Failed Non-Retryable Error...")
+		return executors.NewExecutorResult(
+			executors.Status(pb.TaskExecutionResponseMessage_TASK_STATE_FAILED_NON_RETRYABLE),
+			executors.Error(err),
+		)
+	}
+
+	logger.Info("Returning Task state Completed...")
+	return executors.NewExecutorResult(
+		executors.Output(map[string]string{
+			OBJECT_KEY: result,
+		}),
+		executors.Status(pb.TaskExecutionResponseMessage_TASK_STATE_COMPLETED),
+	)
+}
+
+func (e *KubernetesApiRequestExecutor) ExecuteWithParameters(p *KubernetesApiRequestParameters) (string, error) {
+	if e.client == nil {
+		return "", executors.NewNonRetryableError("Kubernetes API requests are supported only when the Remote Work Processor is running in Kubernetes mode")
+	}
+
+	gvk := schema.FromAPIVersionAndKind(p.apiVersion, p.kind)
+	mapping, err := e.client.GetGVR(&gvk)
+	if err != nil {
+		log.FromContext(p.ctx).Error(err, "Kubernetes API Client: failed to resolve resource type")
+		if meta.IsNoMatchError(err) {
+			return "", executors.NewNonRetryableError("Failed to resolve resource type from %s/%s: %v", p.apiVersion, p.kind, err).WithCause(err)
+		}
+		return "", executors.NewRetryableError("Failed to resolve resource type from %s/%s: %v", p.apiVersion, p.kind, err).WithCause(err)
+	}
+
+	ctx, cancel := context.WithTimeout(p.ctx, p.timeout)
+	defer cancel()
+
+	resource := e.client.GetNamespacedResourceInterface(mapping, p.namespace)
+	logger := log.FromContext(p.ctx)
+	logger.Info("Kubernetes API Client: executing request", "operation", p.operation, "resource", mapping.Resource.String(),
+		"namespace", p.namespace, "name", p.name)
+	result, err := execute(ctx, resource, p)
+	if err != nil {
+		logger.Error(err, "Kubernetes API Client: error occurred while executing request")
+		return "", mapApiError(err, p)
+	}
+
+	if result == nil {
+		return "", nil
+	}
+
+	serialized, err := json.Marshal(result)
+	if err != nil {
+		return "", executors.NewNonRetryableError("Failed to serialize Kubernetes API response: %v", err).WithCause(err)
+	}
+	return string(serialized), nil
+}
+
+func execute(ctx context.Context, resource dyn.ResourceInterface, p *KubernetesApiRequestParameters) (runtime.Unstructured, error) {
+	switch p.operation {
+	case Operation_GET:
+		return resource.Get(ctx, p.name, v1.GetOptions{})
+	case Operation_LIST:
+		return resource.List(ctx, v1.ListOptions{
+			LabelSelector: p.labelSelector,
+			FieldSelector: p.fieldSelector,
+		})
+	case Operation_CREATE:
+		object, err := p.decodeBody()
+		if err != nil {
+			return nil, err
+		}
+		return resource.Create(ctx, object, v1.CreateOptions{FieldManager: p.fieldManager})
+	case Operation_UPDATE:
+		object, err := p.decodeBody()
+		if err != nil {
+			return nil, err
+		}
+		return resource.Update(ctx, object, v1.UpdateOptions{FieldManager: p.fieldManager})
+	case Operation_PATCH:
+		patch, err := yaml.YAMLToJSON([]byte(p.body))
+		if err != nil {
+			return nil, executors.NewNonRetryableError("Input value for key %q is not a valid JSON or YAML document: %v", BODY, err).WithCause(err)
+		}
+		return resource.Patch(ctx, p.name, p.patchType, patch, v1.PatchOptions{FieldManager: p.fieldManager})
+	case Operation_DELETE:
+		return nil, resource.Delete(ctx, p.name, v1.DeleteOptions{})
+	default:
+		return nil, executors.NewNonRetryableError("Unsupported operation %q", p.operation)
+	}
+}
+
+func (p *KubernetesApiRequestParameters) decodeBody() (*unstructured.Unstructured, error) {
+	raw, err := yaml.YAMLToJSON([]byte(p.body))
+	if err != nil {
+		return nil, executors.NewNonRetryableError("Input value for key %q is not a valid JSON or YAML document: %v", BODY, err).WithCause(err)
+	}
+
+	object := &unstructured.Unstructured{}
+	if err = object.UnmarshalJSON(raw); err != nil {
+		return nil, executors.NewNonRetryableError("Input value for key %q is not a valid Kubernetes object: %v", BODY, err).WithCause(err)
+	}
+
+	if object.GetAPIVersion() == "" && object.GetKind() == "" {
+		object.SetAPIVersion(p.apiVersion)
+		object.SetKind(p.kind)
+	} else if object.GetAPIVersion() != p.apiVersion || object.GetKind() != p.kind {
+		return nil, executors.NewNonRetryableError("Object in key %q is of type %s/%s, but %s/%s was requested",
+			BODY, object.GetAPIVersion(), object.GetKind(), p.apiVersion, p.kind)
+	}
+
+	if p.name != "" {
+		object.SetName(p.name)
+	}
+	if p.namespace != "" {
+		object.SetNamespace(p.namespace)
+	}
+	return object, nil
+}
+
+func mapApiError(err error, p *KubernetesApiRequestParameters) error {
+	var nonRetryable *executors.NonRetryableError
+	if errors.As(err, &nonRetryable) {
+		return err
+	}
+
+	switch {
+	case kerrors.IsNotFound(err),
+		kerrors.IsAlreadyExists(err),
+		kerrors.IsInvalid(err),
+		kerrors.IsBadRequest(err),
+		kerrors.IsForbidden(err),
+		kerrors.IsUnauthorized(err),
+		kerrors.IsMethodNotSupported(err),
+		kerrors.IsNotAcceptable(err),
+		kerrors.IsUnsupportedMediaType(err),
+		kerrors.IsRequestEntityTooLargeError(err):
+		return executors.NewNonRetryableError("Kubernetes API request failed: %v\nOperation: %s\nResource: %s/%s %s/%s",
+			err, p.operation, p.apiVersion, p.kind, p.namespace, p.name).WithCause(err)
+	default:
+		return executors.NewRetryableError("Kubernetes API request failed: %v\nOperation: %s\nResource: %s/%s %s/%s",
+			err, p.operation, p.apiVersion, p.kind, p.namespace, p.name).WithCause(err)
+	}
+}
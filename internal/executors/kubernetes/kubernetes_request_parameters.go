@@ -0,0 +1,197 @@
+package kubernetes
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP/remote-work-processor/internal/executors"
+	"github.com/SAP/remote-work-processor/internal/functional"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	OPERATION      string = "operation"
+	API_VERSION    string = "apiVersion"
+	KIND           string = "kind"
+	NAMESPACE      string = "namespace"
+	NAME           string = "name"
+	BODY           string = "body"
+	PATCH_TYPE     string = "patchType"
+	LABEL_SELECTOR string = "labelSelector"
+	FIELD_SELECTOR string = "fieldSelector"
+	FIELD_MANAGER  string = "fieldManager"
+	TIMEOUT        string = "timeout"
+)
+
+const (
+	DefaultFieldManager      = "remote-work-processor"
+	DefaultApiRequestTimeout = 30 * time.Second
+)
+
+var patchTypes = map[string]types.PatchType{
+	"json":      types.JSONPatchType,
+	"merge":     types.MergePatchType,
+	"strategic": types.StrategicMergePatchType,
+	"apply":     types.ApplyPatchType,
+}
+
+type KubernetesApiRequestParameters struct {
+	operation     Operation
+	apiVersion    string
+	kind          string
+	namespace     string
+	name          string
+	body          string
+	patchType     types.PatchType
+	labelSelector string
+	fieldSelector string
+	fieldManager  string
+	timeout       time.Duration
+	ctx           context.Context
+}
+
+func NewKubernetesApiRequestParametersFromContext(ctx executors.Context) (*KubernetesApiRequestParameters, error) {
+	op, err := ctx.GetRequiredString(OPERATION)
+	if err != nil {
+		return nil, executors.NonRetryable(err)
+	}
+
+	operation, err := ParseOperation(op)
+	if err != nil {
+		return nil, executors.NonRetryable(err)
+	}
+
+	apiVersion, err := ctx.GetRequiredString(API_VERSION)
+	if err != nil {
+		return nil, executors.NonRetryable(err)
+	}
+
+	kind, err := ctx.GetRequiredString(KIND)
+	if err != nil {
+		return nil, executors.NonRetryable(err)
+	}
+
+	opts := []functional.OptionWithError[KubernetesApiRequestParameters]{
+		withNamespaceFromContext(ctx),
+		withNameFromContext(ctx),
+		withBodyFromContext(ctx),
+		withPatchTypeFromContext(ctx),
+		withLabelSelectorFromContext(ctx),
+		withFieldSelectorFromContext(ctx),
+		withFieldManagerFromContext(ctx),
+		withTimeoutFromContext(ctx),
+		WithContext(ctx.GetContext()),
+	}
+	return NewKubernetesApiRequestParameters(operation, apiVersion, kind, opts...)
+}
+
+func NewKubernetesApiRequestParameters(operation Operation, apiVersion, kind string,
+	opts ...functional.OptionWithError[KubernetesApiRequestParameters]) (*KubernetesApiRequestParameters, error) {
+	p := &KubernetesApiRequestParameters{
+		operation:    operation,
+		apiVersion:   apiVersion,
+		kind:         kind,
+		patchType:    types.MergePatchType,
+		fieldManager: DefaultFieldManager,
+		timeout:      DefaultApiRequestTimeout,
+		ctx:          context.Background(),
+	}
+
+	for _, opt := range opts {
+		if err := opt(p); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.operation.requiresName() && p.name == "" {
+		return nil, executors.NonRetryable(executors.NewRequiredKeyValidationError(NAME))
+	}
+
+	if p.operation.requiresBody() && p.body == "" {
+		return nil, executors.NonRetryable(executors.NewRequiredKeyValidationError(BODY))
+	}
+	return p, nil
+}
+
+// WithContext sets the context aborting the request when cancelled.
+func WithContext(ctx context.Context) functional.OptionWithError[KubernetesApiRequestParameters] {
+	return func(p *KubernetesApiRequestParameters) error {
+		p.ctx = ctx
+		return nil
+	}
+}
+
+func withNamespaceFromContext(ctx executors.Context) functional.OptionWithError[KubernetesApiRequestParameters] {
+	return func(params *KubernetesApiRequestParameters) error {
+		params.namespace = ctx.GetString(NAMESPACE)
+		return nil
+	}
+}
+
+func withNameFromContext(ctx executors.Context) functional.OptionWithError[KubernetesApiRequestParameters] {
+	return func(params *KubernetesApiRequestParameters) error {
+		params.name = ctx.GetString(NAME)
+		return nil
+	}
+}
+
+func withBodyFromContext(ctx executors.Context) functional.OptionWithError[KubernetesApiRequestParameters] {
+	return func(params *KubernetesApiRequestParameters) error {
+		params.body = ctx.GetString(BODY)
+		return nil
+	}
+}
+
+func withPatchTypeFromContext(ctx executors.Context) functional.OptionWithError[KubernetesApiRequestParameters] {
+	return func(params *KubernetesApiRequestParameters) error {
+		pt := ctx.GetString(PATCH_TYPE)
+		if pt == "" {
+			return nil
+		}
+
+		patchType, ok := patchTypes[pt]
+		if !ok {
+			return executors.NewNonRetryableError("Input value %q for key %q is not a valid patch type [json, merge, strategic, apply]", pt, PATCH_TYPE)
+		}
+
+		params.patchType = patchType
+		return nil
+	}
+}
+
+func withLabelSelectorFromContext(ctx executors.Context) functional.OptionWithError[KubernetesApiRequestParameters] {
+	return func(params *KubernetesApiRequestParameters) error {
+		params.labelSelector = ctx.GetString(LABEL_SELECTOR)
+		return nil
+	}
+}
+
+func withFieldSelectorFromContext(ctx executors.Context) functional.OptionWithError[KubernetesApiRequestParameters] {
+	return func(params *KubernetesApiRequestParameters) error {
+		params.fieldSelector = ctx.GetString(FIELD_SELECTOR)
+		return nil
+	}
+}
+
+func withFieldManagerFromContext(ctx executors.Context) functional.OptionWithError[KubernetesApiRequestParameters] {
+	return func(params *KubernetesApiRequestParameters) error {
+		if fm := ctx.GetString(FIELD_MANAGER); fm != "" {
+			params.fieldManager = fm
+		}
+		return nil
+	}
+}
+
+func withTimeoutFromContext(ctx executors.Context) functional.OptionWithError[KubernetesApiRequestParameters] {
+	return func(params *KubernetesApiRequestParameters) error {
+		timeout, err := ctx.GetNumber(TIMEOUT)
+		if err != nil {
+			return executors.NonRetryable(err)
+		}
+
+		if timeout != 0 {
+			params.timeout = time.Duration(timeout) * time.Second
+		}
+		return nil
+	}
+}
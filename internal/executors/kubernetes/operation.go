@@ -0,0 +1,35 @@
+package kubernetes
+
+import "fmt"
+
+type Operation string
+
+const (
+	Operation_GET    Operation = "get"
+	Operation_LIST   Operation = "list"
+	Operation_CREATE Operation = "create"
+	Operation_UPDATE Operation = "update"
+	Operation_PATCH  Operation = "patch"
+	Operation_DELETE Operation = "delete"
+)
+
+var (
+	operations = []Operation{Operation_GET, Operation_LIST, Operation_CREATE, Operation_UPDATE, Operation_PATCH, Operation_DELETE}
+)
+
+func ParseOperation(s string) (Operation, error) {
+	for _, op := range operations {
+		if string(op) == s {
+			return op, nil
+		}
+	}
+	return "", fmt.Errorf("invalid value for operation %q: expected one of %v", s, operations)
+}
+
+func (op Operation) requiresName() bool {
+	return op == Operation_GET || op == Operation_PATCH || op == Operation_DELETE
+}
+
+func (op Operation) requiresBody() bool {
+	return op == Operation_CREATE || op == Operation_UPDATE || op == Operation_PATCH
+}
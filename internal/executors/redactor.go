@@ -0,0 +1,116 @@
+package executors
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	REDACTED = "<redacted>"
+
+	// shorter secrets, e.g. "1", "true" or "false", are not redacted, as they would corrupt all log lines written meanwhile
+	minSecretLength = 6
+)
+
+// sensitiveInputKeys are the input keys whose values are scrubbed from the logs and errors of sensitive tasks.
+var sensitiveInputKeys = []string{"password", "clientSecret", "refreshToken", "authorizationHeader", "clientCert"}
+
+// Redactor replaces the secrets found in the input of a task.
+type Redactor struct {
+	secrets []string
+}
+
+func NewRedactor(input map[string]string) *Redactor {
+	secrets := make([]string, 0, len(sensitiveInputKeys))
+	for _, k := range sensitiveInputKeys {
+		if v := strings.TrimSpace(input[k]); len(v) >= minSecretLength {
+			secrets = append(secrets, v)
+			// the log output is JSON-encoded before it reaches the redacting writers
+			if escaped := jsonEscaped(v); escaped != v {
+				secrets = append(secrets, escaped)
+			}
+		}
+	}
+
+	// longer secrets go first, so that a secret containing another one is not left partially visible
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+	return &Redactor{
+		secrets: secrets,
+	}
+}
+
+// jsonEscaped returns the string as it is quoted in JSON, without the quotes.
+func jsonEscaped(s string) string {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+
+	quoted := strings.TrimSuffix(b.String(), "\n")
+	return quoted[1 : len(quoted)-1]
+}
+
+func (r *Redactor) Redact(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, REDACTED)
+	}
+	return s
+}
+
+var activeRedactors = &redactorSet{
+	redactors: make(map[*Redactor]struct{}),
+}
+
+type redactorSet struct {
+	sync.RWMutex
+	redactors map[*Redactor]struct{}
+}
+
+// ActivateLogRedaction makes the writers created by NewRedactingWriter scrub the secrets of the redactor
+// until the returned function is called.
+func ActivateLogRedaction(r *Redactor) func() {
+	activeRedactors.Lock()
+	activeRedactors.redactors[r] = struct{}{}
+	activeRedactors.Unlock()
+
+	return func() {
+		activeRedactors.Lock()
+		delete(activeRedactors.redactors, r)
+		activeRedactors.Unlock()
+	}
+}
+
+type redactingWriter struct {
+	out io.Writer
+}
+
+// NewRedactingWriter wraps the writer, scrubbing the secrets of all sensitive tasks in progress from the output.
+func NewRedactingWriter(out io.Writer) io.Writer {
+	return redactingWriter{
+		out: out,
+	}
+}
+
+func (w redactingWriter) Write(p []byte) (int, error) {
+	activeRedactors.RLock()
+	if len(activeRedactors.redactors) == 0 {
+		activeRedactors.RUnlock()
+		return w.out.Write(p)
+	}
+
+	s := string(p)
+	for r := range activeRedactors.redactors {
+		s = r.Redact(s)
+	}
+	activeRedactors.RUnlock()
+
+	if _, err := io.WriteString(w.out, s); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
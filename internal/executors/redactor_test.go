@@ -0,0 +1,61 @@
+package executors
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestRedactorSkipsShortSecrets(t *testing.T) {
+	r := NewRedactor(map[string]string{"password": "1", "clientSecret": "false", "refreshToken": "s3cr3t"})
+
+	got := r.Redact("execution 1 of false task with s3cr3t")
+	want := "execution 1 of false task with " + REDACTED
+	if got != want {
+		t.Errorf("Redact() = %q, want %q", got, want)
+	}
+}
+
+func TestRedactingWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret string
+	}{
+		{name: "plain", secret: "s3cr3t-value"},
+		{name: "quote", secret: `pass"word`},
+		{name: "backslash", secret: `pass\word`},
+		{name: "control characters", secret: "pass\nword\x01\t"},
+		{name: "html characters", secret: "<pass&word>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			logger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+				zapcore.AddSync(NewRedactingWriter(&out)), zap.InfoLevel))
+
+			deactivate := ActivateLogRedaction(NewRedactor(map[string]string{"password": tt.secret}))
+			logger.Info("message with "+tt.secret, zap.String("field", "before"+tt.secret+"after"))
+			deactivate()
+			logger.Info("after deactivation", zap.String("field", "s3cr3t-value"))
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			if len(lines) != 2 {
+				t.Fatalf("expected 2 log lines, got %d: %q", len(lines), out.String())
+			}
+			escaped := jsonEscaped(strings.TrimSpace(tt.secret))
+			if strings.Contains(lines[0], escaped) {
+				t.Errorf("secret %q leaked: %s", escaped, lines[0])
+			}
+			if strings.Count(lines[0], REDACTED) != 2 {
+				t.Errorf("expected the secret to be redacted twice: %s", lines[0])
+			}
+			if !strings.Contains(lines[1], "s3cr3t-value") {
+				t.Errorf("expected no redaction after deactivation: %s", lines[1])
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package executors
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	pb "github.com/SAP/remote-work-processor/build/proto/generated"
+)
+
+const (
+	// DefaultTaskVersion is the version of tasks which do not specify one.
+	DefaultTaskVersion int32 = 1
+	// AnyVersion matches every task version for which no dedicated constructor has been registered.
+	AnyVersion int32 = -1
+)
+
+type Constructor func() Executor
+
+type registryKey struct {
+	taskType pb.TaskType
+	version  int32
+}
+
+// Registry maps task types, and optionally task versions, to executor constructors.
+type Registry struct {
+	sync.RWMutex
+	constructors map[registryKey]Constructor
+	// executors usually register from init functions, before the logger has been set up,
+	// hence the replacements are recorded to be logged later on
+	replaced []string
+}
+
+// DefaultRegistry is the registry executors register themselves into, usually from an init function.
+var DefaultRegistry = NewRegistry()
+
+func NewRegistry() *Registry {
+	return &Registry{
+		constructors: make(map[registryKey]Constructor),
+	}
+}
+
+// Register binds the constructor to the given task type and versions. Without versions the constructor serves
+// every version of the task type. A constructor already registered for the same type and version is replaced,
+// which allows custom executors to take over the built-in ones.
+func (r *Registry) Register(t pb.TaskType, constructor Constructor, versions ...int32) {
+	r.Lock()
+	defer r.Unlock()
+
+	if len(versions) == 0 {
+		versions = []int32{AnyVersion}
+	}
+
+	for _, v := range versions {
+		key := registryKey{taskType: t, version: v}
+		if _, ok := r.constructors[key]; ok {
+			r.replaced = append(r.replaced, fmt.Sprintf("%s (version %d)", t, v))
+		}
+		r.constructors[key] = constructor
+	}
+}
+
+// RegisterIfAbsent binds the constructor to the given task type and versions, unless an executor has been registered
+// for them already, including one serving every version of the task type. It registers built-in executors which
+// cannot register themselves from an init function, without replacing custom ones.
+func (r *Registry) RegisterIfAbsent(t pb.TaskType, constructor Constructor, versions ...int32) {
+	r.Lock()
+	defer r.Unlock()
+
+	if len(versions) == 0 {
+		versions = []int32{AnyVersion}
+	}
+
+	if _, ok := r.constructors[registryKey{taskType: t, version: AnyVersion}]; ok {
+		return
+	}
+	for _, v := range versions {
+		key := registryKey{taskType: t, version: v}
+		if _, ok := r.constructors[key]; !ok {
+			r.constructors[key] = constructor
+		}
+	}
+}
+
+// Create instantiates the executor registered for the given task type and version. An unsupported version of
+// a known task type results in a NonRetryableError, as retrying the task with the same input is pointless.
+func (r *Registry) Create(t pb.TaskType, version int32) (Executor, error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	if version == 0 {
+		version = DefaultTaskVersion
+	}
+
+	if constructor, ok := r.constructors[registryKey{taskType: t, version: version}]; ok {
+		return constructor(), nil
+	}
+	if constructor, ok := r.constructors[registryKey{taskType: t, version: AnyVersion}]; ok {
+		return constructor(), nil
+	}
+
+	if versions := r.versions(t); len(versions) > 0 {
+		return nil, NewNonRetryableError("cannot create executor of type %q: unsupported task version %d (supported versions: %v)",
+			t, version, versions)
+	}
+	return nil, fmt.Errorf("cannot create executor of type %q: unsupported task type", t)
+}
+
+// SupportedTypes returns the task types with at least one registered executor, ordered by their enum value.
+func (r *Registry) SupportedTypes() []pb.TaskType {
+	r.RLock()
+	defer r.RUnlock()
+
+	seen := make(map[pb.TaskType]struct{})
+	types := make([]pb.TaskType, 0)
+	for key := range r.constructors {
+		if _, ok := seen[key.taskType]; !ok {
+			seen[key.taskType] = struct{}{}
+			types = append(types, key.taskType)
+		}
+	}
+
+	sort.Slice(types, func(i, j int) bool {
+		return types[i] < types[j]
+	})
+	return types
+}
+
+// Replaced returns the task types, along with their versions, whose executor has been replaced by another one.
+func (r *Registry) Replaced() []string {
+	r.RLock()
+	defer r.RUnlock()
+
+	return append([]string(nil), r.replaced...)
+}
+
+func (r *Registry) versions(t pb.TaskType) []int32 {
+	versions := make([]int32, 0)
+	for key := range r.constructors {
+		if key.taskType == t {
+			versions = append(versions, key.version)
+		}
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i] < versions[j]
+	})
+	return versions
+}
+
+// Register binds the constructor to the given task type and versions in the DefaultRegistry.
+func Register(t pb.TaskType, constructor Constructor, versions ...int32) {
+	DefaultRegistry.Register(t, constructor, versions...)
+}
+
+// RegisterIfAbsent binds the constructor to the given task type and versions in the DefaultRegistry,
+// unless an executor has been registered for them already.
+func RegisterIfAbsent(t pb.TaskType, constructor Constructor, versions ...int32) {
+	DefaultRegistry.RegisterIfAbsent(t, constructor, versions...)
+}
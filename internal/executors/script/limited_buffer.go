@@ -0,0 +1,37 @@
+package script
+
+import "bytes"
+
+// limitedBuffer keeps at most limit bytes of the written data and silently discards the rest,
+// so that a chatty script cannot exhaust the memory of the Remote Work Processor.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     uint64
+	truncated bool
+}
+
+func newLimitedBuffer(limit uint64) *limitedBuffer {
+	return &limitedBuffer{
+		limit: limit,
+	}
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	remaining := b.limit - uint64(b.buf.Len())
+	if uint64(len(p)) > remaining {
+		b.buf.Write(p[:remaining])
+		b.truncated = true
+	} else {
+		b.buf.Write(p)
+	}
+	// report the full length, otherwise the process pipe copying would fail with io.ErrShortWrite
+	return len(p), nil
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
+
+func (b *limitedBuffer) Truncated() bool {
+	return b.truncated
+}
@@ -0,0 +1,25 @@
+//go:build !windows
+
+package script
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+const (
+	defaultInterpreter = "/bin/sh"
+	defaultPath        = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+	scriptFileName     = "script"
+)
+
+var defaultInterpreterArgs []string
+
+// isolateProcess starts the script in its own process group, so that the whole process tree
+// spawned by the script is killed when the execution is cancelled or times out.
+func isolateProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
@@ -0,0 +1,19 @@
+//go:build windows
+
+package script
+
+import "os/exec"
+
+const (
+	defaultInterpreter = "powershell.exe"
+	defaultPath        = `C:\Windows\System32;C:\Windows;C:\Windows\System32\WindowsPowerShell\v1.0`
+	scriptFileName     = "script.ps1"
+)
+
+var defaultInterpreterArgs = []string{"-NoProfile", "-NonInteractive", "-File"}
+
+func isolateProcess(cmd *exec.Cmd) {
+	cmd.Cancel = func() error {
+		return cmd.Process.Kill()
+	}
+}
@@ -0,0 +1,199 @@
+package script
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/SAP/remote-work-processor/build/proto/generated"
+	"github.com/SAP/remote-work-processor/internal/executors"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	EXIT_CODE_KEY = "exitCode"
+	STDOUT_KEY    = "stdout"
+	STDERR_KEY    = "stderr"
+)
+
+// waitDelay bounds the time spent waiting for the output pipes to close after the script has been killed,
+// in case the script has left behind processes which still hold them.
+const waitDelay = 5 * time.Second
+
+func init() {
+	executors.Register(pb.TaskType_TASK_TYPE_SCRIPT, func() executors.Executor {
+		return NewScriptExecutor()
+	}, executors.DefaultTaskVersion)
+}
+
+type ScriptExecutor struct {
+	executors.Executor
+}
+
+type ScriptResult struct {
+	exitCode int
+	stdout   *limitedBuffer
+	stderr   *limitedBuffer
+}
+
+func NewScriptExecutor() *ScriptExecutor {
+	return &ScriptExecutor{}
+}
+
+func (e *ScriptExecutor) Execute(ctx executors.Context) *executors.ExecutorResult {
+	logger := log.FromContext(ctx.GetContext())
+	logger.Info("Executing Script command...")
+	params, err := NewScriptParametersFromContext(ctx)
+	if err != nil {
+		logger.Error(err, "Could not create script params: returning Task state Failed Non-Retryable Error")
+		return executors.NewExecutorResult(
+			executors.Status(pb.TaskExecutionResponseMessage_TASK_STATE_FAILED_NON_RETRYABLE),
+			executors.Error(err),
+		)
+	}
+
+	res, err := e.ExecuteWithParameters(params)
+	if err != nil {
+		var retryable *executors.RetryableError
+		if errors.As(err, &retryable) {
+			logger.Info("Returning Task state Failed Retryable Error...")
+			return executors.NewExecutorResult(
+				executors.Output(res.toMap()),
+				executors.Status(pb.TaskExecutionResponseMessage_TASK_STATE_FAILED_RETRYABLE),
+				executors.Error(err),
+			)
+		}
+		logger.Info("Returning Task state Failed Non-Retryable Error...")
+		return executors.NewExecutorResult(
+			executors.Output(res.toMap()),
+			executors.Status(pb.TaskExecutionResponseMessage_TASK_STATE_FAILED_NON_RETRYABLE),
+			executors.Error(err),
+		)
+	}
+
+	logger.Info("Returning Task state Completed...")
+	return executors.NewExecutorResult(
+		executors.Output(res.toMap()),
+		executors.Status(pb.TaskExecutionResponseMessage_TASK_STATE_COMPLETED),
+	)
+}
+
+func (e *ScriptExecutor) ExecuteWithParameters(p *ScriptParameters) (*ScriptResult, error) {
+	res := &ScriptResult{
+		exitCode: -1,
+		stdout:   newLimitedBuffer(p.maxOutputSize),
+		stderr:   newLimitedBuffer(p.maxOutputSize),
+	}
+
+	dir, err := os.MkdirTemp("", "rwp-script-")
+	if err != nil {
+		return res, executors.NewRetryableError("Failed to create script directory: %v", err).WithCause(err)
+	}
+	defer os.RemoveAll(dir)
+
+	scriptPath := filepath.Join(dir, scriptFileName)
+	if err = os.WriteFile(scriptPath, []byte(p.script), 0700); err != nil {
+		return res, executors.NewRetryableError("Failed to write script file: %v", err).WithCause(err)
+	}
+
+	ctx, cancel := context.WithTimeout(p.ctx, p.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, p.interpreter, p.buildArgs(scriptPath)...)
+	cmd.Env = p.buildEnv()
+	cmd.Stdin = strings.NewReader(p.stdin)
+	cmd.Stdout = res.stdout
+	cmd.Stderr = res.stderr
+	cmd.WaitDelay = waitDelay
+	if p.workingDirectory != "" {
+		cmd.Dir = p.workingDirectory
+	} else {
+		cmd.Dir = dir
+	}
+	isolateProcess(cmd)
+
+	logger := log.FromContext(p.ctx)
+	logger.Info("Script executor: running script", "interpreter", p.interpreter, "timeout", p.timeout.String())
+	err = cmd.Run()
+	res.logTruncation(logger)
+
+	if p.ctx.Err() != nil {
+		logger.Info("Script executor: script cancelled", "reason", p.ctx.Err().Error())
+		return res, executors.NewRetryableError("Script execution cancelled: %v", p.ctx.Err()).WithCause(p.ctx.Err())
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		logger.Info("Script executor: script timed out", "timeout", p.timeout.String())
+		return res, executors.NewRetryableError("Script execution timed out after %s", p.timeout).WithCause(ctx.Err())
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		res.exitCode = exitErr.ExitCode()
+		logger.Info("Script executor: script exited", "exit_code", res.exitCode)
+		if p.isRetryableExitCode(res.exitCode) {
+			return res, executors.NewRetryableError("Script execution failed\nExit code: %d", res.exitCode).WithCause(err)
+		}
+		return res, executors.NewNonRetryableError("Script execution failed\nExit code: %d", res.exitCode).WithCause(err)
+	}
+
+	if err != nil {
+		logger.Error(err, "Script executor: could not run script")
+		return res, executors.NewNonRetryableError("Could not run script with interpreter %q: %v", p.interpreter, err).WithCause(err)
+	}
+
+	res.exitCode = cmd.ProcessState.ExitCode()
+	logger.Info("Script executor: script exited", "exit_code", res.exitCode)
+	return res, nil
+}
+
+func (p *ScriptParameters) buildArgs(scriptPath string) []string {
+	var args []string
+	if p.interpreter == defaultInterpreter {
+		args = append(args, defaultInterpreterArgs...)
+	}
+	args = append(args, scriptPath)
+	return append(args, p.args...)
+}
+
+// buildEnv does not inherit the environment of the Remote Work Processor,
+// as it contains the credentials used for the connection to AutoPi.
+func (p *ScriptParameters) buildEnv() []string {
+	env := make([]string, 0, len(p.env)+1)
+	if _, ok := p.env["PATH"]; !ok {
+		env = append(env, "PATH="+defaultPath)
+	}
+
+	for k, v := range p.env {
+		env = append(env, fmt.Sprintf("%s=%s", k, v))
+	}
+	return env
+}
+
+func (r *ScriptResult) logTruncation(logger logr.Logger) {
+	if r.stdout.Truncated() {
+		logger.Info("Script executor: stdout exceeded the maximum output size and has been truncated")
+	}
+	if r.stderr.Truncated() {
+		logger.Info("Script executor: stderr exceeded the maximum output size and has been truncated")
+	}
+}
+
+func (r *ScriptResult) toMap() map[string]string {
+	if r == nil {
+		return nil
+	}
+
+	return map[string]string{
+		EXIT_CODE_KEY: strconv.Itoa(r.exitCode),
+		STDOUT_KEY:    r.stdout.String(),
+		STDERR_KEY:    r.stderr.String(),
+	}
+}
@@ -0,0 +1,194 @@
+package script
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/SAP/remote-work-processor/internal/executors"
+	"github.com/SAP/remote-work-processor/internal/functional"
+)
+
+const (
+	SCRIPT               string = "script"
+	INTERPRETER          string = "interpreter"
+	ARGS                 string = "args"
+	STDIN                string = "stdin"
+	ENV                  string = "env"
+	WORKING_DIRECTORY    string = "workingDirectory"
+	TIMEOUT              string = "timeout"
+	MAX_OUTPUT_SIZE      string = "maxOutputSize"
+	RETRYABLE_EXIT_CODES string = "retryableExitCodes"
+)
+
+const (
+	DefaultScriptTimeout = 60 * time.Second
+	DefaultMaxOutputSize = 1 << 20
+)
+
+type ScriptParameters struct {
+	script             string
+	interpreter        string
+	args               []string
+	stdin              string
+	env                map[string]string
+	workingDirectory   string
+	timeout            time.Duration
+	maxOutputSize      uint64
+	retryableExitCodes []int
+	ctx                context.Context
+}
+
+func NewScriptParametersFromContext(ctx executors.Context) (*ScriptParameters, error) {
+	script, err := ctx.GetRequiredString(SCRIPT)
+	if err != nil {
+		return nil, executors.NonRetryable(err)
+	}
+
+	opts := []functional.OptionWithError[ScriptParameters]{
+		withInterpreterFromContext(ctx),
+		withArgsFromContext(ctx),
+		withStdinFromContext(ctx),
+		withEnvFromContext(ctx),
+		withWorkingDirectoryFromContext(ctx),
+		withTimeoutFromContext(ctx),
+		withMaxOutputSizeFromContext(ctx),
+		withRetryableExitCodesFromContext(ctx),
+		WithContext(ctx.GetContext()),
+	}
+	return NewScriptParameters(script, opts...)
+}
+
+func NewScriptParameters(script string, opts ...functional.OptionWithError[ScriptParameters]) (*ScriptParameters, error) {
+	p := &ScriptParameters{
+		script:        script,
+		interpreter:   defaultInterpreter,
+		timeout:       DefaultScriptTimeout,
+		maxOutputSize: DefaultMaxOutputSize,
+		ctx:           context.Background(),
+	}
+
+	for _, opt := range opts {
+		if err := opt(p); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+func (p *ScriptParameters) isRetryableExitCode(code int) bool {
+	for _, c := range p.retryableExitCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// WithContext sets the context killing the script when cancelled.
+func WithContext(ctx context.Context) functional.OptionWithError[ScriptParameters] {
+	return func(p *ScriptParameters) error {
+		p.ctx = ctx
+		return nil
+	}
+}
+
+func withInterpreterFromContext(ctx executors.Context) functional.OptionWithError[ScriptParameters] {
+	return func(params *ScriptParameters) error {
+		if i := ctx.GetString(INTERPRETER); i != "" {
+			params.interpreter = i
+		}
+		return nil
+	}
+}
+
+func withArgsFromContext(ctx executors.Context) functional.OptionWithError[ScriptParameters] {
+	return func(params *ScriptParameters) error {
+		args, err := ctx.GetList(ARGS)
+		if err != nil {
+			return executors.NonRetryable(err)
+		}
+
+		params.args = args
+		return nil
+	}
+}
+
+func withStdinFromContext(ctx executors.Context) functional.OptionWithError[ScriptParameters] {
+	return func(params *ScriptParameters) error {
+		params.stdin = ctx.GetString(STDIN)
+		return nil
+	}
+}
+
+func withEnvFromContext(ctx executors.Context) functional.OptionWithError[ScriptParameters] {
+	return func(params *ScriptParameters) error {
+		env, err := ctx.GetMap(ENV)
+		if err != nil {
+			return executors.NonRetryable(err)
+		}
+
+		params.env = env
+		return nil
+	}
+}
+
+func withWorkingDirectoryFromContext(ctx executors.Context) functional.OptionWithError[ScriptParameters] {
+	return func(params *ScriptParameters) error {
+		params.workingDirectory = ctx.GetString(WORKING_DIRECTORY)
+		return nil
+	}
+}
+
+func withTimeoutFromContext(ctx executors.Context) functional.OptionWithError[ScriptParameters] {
+	return func(params *ScriptParameters) error {
+		timeout, err := ctx.GetNumber(TIMEOUT)
+		if err != nil {
+			return executors.NonRetryable(err)
+		}
+
+		if timeout != 0 {
+			params.timeout = time.Duration(timeout) * time.Second
+		}
+		return nil
+	}
+}
+
+func withMaxOutputSizeFromContext(ctx executors.Context) functional.OptionWithError[ScriptParameters] {
+	return func(params *ScriptParameters) error {
+		size, err := ctx.GetNumber(MAX_OUTPUT_SIZE)
+		if err != nil {
+			return executors.NonRetryable(err)
+		}
+
+		if size != 0 {
+			params.maxOutputSize = size
+		}
+		return nil
+	}
+}
+
+// withRetryableExitCodesFromContext accepts the exit codes as JSON numbers or as strings, e.g. [75] or ["75"].
+func withRetryableExitCodesFromContext(ctx executors.Context) functional.OptionWithError[ScriptParameters] {
+	return func(params *ScriptParameters) error {
+		s := ctx.GetString(RETRYABLE_EXIT_CODES)
+		if s == "" {
+			return nil
+		}
+
+		var codes []json.Number
+		if err := json.Unmarshal([]byte(s), &codes); err != nil {
+			return executors.NewNonRetryableError("Input value %q for key %q is not a list of exit codes", s, RETRYABLE_EXIT_CODES).WithCause(err)
+		}
+
+		for _, c := range codes {
+			code, err := strconv.Atoi(c.String())
+			if err != nil {
+				return executors.NewNonRetryableError("Input value %q for key %q is not a valid exit code", c, RETRYABLE_EXIT_CODES).WithCause(err)
+			}
+			params.retryableExitCodes = append(params.retryableExitCodes, code)
+		}
+		return nil
+	}
+}
@@ -3,17 +3,23 @@ package void
 import (
 	pb "github.com/SAP/remote-work-processor/build/proto/generated"
 	"github.com/SAP/remote-work-processor/internal/executors"
-	"log"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const (
 	MESSAGE_KEY = "message"
 )
 
+func init() {
+	executors.Register(pb.TaskType_TASK_TYPE_VOID, func() executors.Executor {
+		return VoidExecutor{}
+	}, executors.DefaultTaskVersion)
+}
+
 type VoidExecutor struct{}
 
 func (VoidExecutor) Execute(ctx executors.Context) *executors.ExecutorResult {
-	log.Println("Executing Void command...")
+	log.FromContext(ctx.GetContext()).Info("Executing Void command...")
 	msg := ctx.GetString(MESSAGE_KEY)
 	return executors.NewExecutorResult(
 		executors.Output(buildOutput(msg)),
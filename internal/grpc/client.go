@@ -2,30 +2,45 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"os"
 	"sync"
 	"time"
 
 	pb "github.com/SAP/remote-work-processor/build/proto/generated"
 	meta "github.com/SAP/remote-work-processor/internal/kubernetes/metadata"
+	"github.com/SAP/remote-work-processor/internal/metrics"
+	"github.com/go-logr/logr"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	ErrSessionClosed         = errors.New("gRPC session is closed")
+	ErrSessionClosedByServer = errors.New("gRPC session has been closed by the server")
 )
 
 type RemoteWorkProcessorGrpcClient struct {
 	sync.Mutex
 	metadata  *ClientMetadata
+	conn      *grpc.ClientConn
 	stream    pb.RemoteWorkProcessorService_SessionClient
+	baseCtx   context.Context
 	context   context.Context
 	cancelCtx context.CancelFunc
+	logger    logr.Logger
+
+	confirmedConfigVersion string
+	heartbeatErr           error
 }
 
-func NewClient(metadata meta.RemoteWorkProcessorMetadata, isStandaloneMode bool) *RemoteWorkProcessorGrpcClient {
+// NewClient creates a client for the server. The context bounds the lifetime of the client certificate watcher.
+func NewClient(ctx context.Context, metadata meta.RemoteWorkProcessorMetadata, isStandaloneMode bool) *RemoteWorkProcessorGrpcClient {
 	clientMetadata := NewClientMetadata(metadata.AutoPiHost(), metadata.AutoPiPort(), isStandaloneMode).
 		WithBinaryVersion(metadata.BinaryVersion())
 
@@ -33,11 +48,12 @@ func NewClient(metadata meta.RemoteWorkProcessorMetadata, isStandaloneMode bool)
 	if isLocaldev {
 		clientMetadata.WithInsecureTransport()
 	} else {
-		clientMetadata.WithClientCertificate()
+		clientMetadata.WithClientCertificate(ctx)
 	}
 
 	return &RemoteWorkProcessorGrpcClient{
 		metadata: clientMetadata,
+		logger:   log.Log,
 	}
 }
 
@@ -48,83 +64,155 @@ func (gc *RemoteWorkProcessorGrpcClient) InitSession(baseCtx context.Context, se
 	default:
 	}
 
-	log.Println("Initiating session", sessionID)
+	logger := log.FromContext(baseCtx)
+	logger.Info("Initiating session")
 	ctx, cancel := context.WithCancel(baseCtx)
 	ctx = metadata.NewOutgoingContext(ctx, metadata.New(map[string]string{
 		"X-AutoPilot-SessionId":     sessionID,
 		"X-AutoPilot-BinaryVersion": gc.metadata.GetBinaryVersion(),
 	}))
-	gc.context = ctx
-	gc.cancelCtx = cancel
 
-	rpc, err := gc.establishConnection(ctx)
+	conn, err := gc.establishConnection(ctx)
 	if err != nil {
+		cancel()
 		return err
 	}
-	return gc.startSession(rpc, ctx)
+
+	stream, err := gc.startSession(pb.NewRemoteWorkProcessorServiceClient(conn), ctx)
+	if err != nil {
+		cancel()
+		conn.Close()
+		return err
+	}
+
+	gc.Lock()
+	gc.conn = conn
+	gc.stream = stream
+	gc.baseCtx = baseCtx
+	gc.context = ctx
+	gc.cancelCtx = cancel
+	gc.logger = logger
+	gc.heartbeatErr = nil
+	gc.Unlock()
+
+	go gc.runHeartbeat(ctx)
+	return nil
 }
 
 func (gc *RemoteWorkProcessorGrpcClient) Send(op *pb.ClientMessage) error {
+	gc.Lock()
+	defer gc.Unlock()
+
+	if gc.stream == nil {
+		return ErrSessionClosed
+	}
+
 	select {
 	case <-gc.context.Done():
 		gc.closeConn()
-		return nil
+		return ErrSessionClosed
 	default:
 	}
 
-	gc.Lock()
-	defer gc.Unlock()
-
 	if err := gc.stream.Send(op); err != nil {
 		gc.closeConn()
 		return fmt.Errorf("error occured while sending client message: %v", err)
 	}
+
+	metrics.RecordMessage(metrics.DirectionSent, op)
+	if confirm, ok := op.Body.(*pb.ClientMessage_ConfirmConfigUpdate); ok {
+		gc.confirmedConfigVersion = confirm.ConfirmConfigUpdate.GetConfigVersion()
+	}
 	return nil
 }
 
 func (gc *RemoteWorkProcessorGrpcClient) ReceiveMsg() (*pb.ServerMessage, error) {
-	log.Println("Waiting for server message...")
-	msg, err := gc.stream.Recv()
+	gc.Lock()
+	stream := gc.stream
+	logger := gc.logger
+	gc.Unlock()
+
+	if stream == nil {
+		return nil, ErrSessionClosed
+	}
+
+	logger.V(1).Info("Waiting for server message...")
+	msg, err := stream.Recv()
 	if err == io.EOF {
-		log.Println("Server closed the connection. Stopping Remote Work Processor...")
-		gc.closeConn()
-		return nil, nil
+		// e.g. on a rollout of the server, the session is reestablished
+		logger.Info("Server closed the session")
+		gc.CloseSession()
+		return nil, ErrSessionClosedByServer
 	}
 
 	if err != nil {
 		rpcErr, isRpcErr := status.FromError(err)
-		if isRpcErr && rpcErr.Code() == codes.Canceled {
-			log.Println("Context cancelled. Stopping Remote Work Processor...")
+		if isRpcErr && rpcErr.Code() == codes.Canceled && gc.baseCtx.Err() != nil {
+			logger.Info("Context cancelled. Stopping Remote Work Processor...")
 			return nil, nil
 		}
 		return nil, fmt.Errorf("error occurred while receiving message from server: %v", err)
 	}
+
+	metrics.RecordMessage(metrics.DirectionReceived, msg)
 	return msg, nil
 }
 
-func (gc *RemoteWorkProcessorGrpcClient) establishConnection(ctx context.Context) (pb.RemoteWorkProcessorServiceClient, error) {
+// CloseSession terminates the current session, if any. It is safe to be called multiple times.
+func (gc *RemoteWorkProcessorGrpcClient) CloseSession() {
+	gc.Lock()
+	defer gc.Unlock()
+
+	if gc.stream != nil {
+		gc.closeConn()
+	}
+}
+
+// IsSessionActive reports whether a session with the server is currently established.
+func (gc *RemoteWorkProcessorGrpcClient) IsSessionActive() bool {
+	gc.Lock()
+	defer gc.Unlock()
+
+	return gc.stream != nil
+}
+
+// GetHeartbeatError returns the error of the last failed heartbeat of the current session, if any.
+func (gc *RemoteWorkProcessorGrpcClient) GetHeartbeatError() error {
+	gc.Lock()
+	defer gc.Unlock()
+
+	return gc.heartbeatErr
+}
+
+// GetConfirmedConfigVersion returns the last watch config version successfully confirmed to the server.
+func (gc *RemoteWorkProcessorGrpcClient) GetConfirmedConfigVersion() string {
+	gc.Lock()
+	defer gc.Unlock()
+
+	return gc.confirmedConfigVersion
+}
+
+func (gc *RemoteWorkProcessorGrpcClient) establishConnection(ctx context.Context) (*grpc.ClientConn, error) {
 	target := fmt.Sprintf("%s:%s", gc.metadata.GetHost(), gc.metadata.GetPort())
-	log.Println("Connecting to AutoPi at", target)
+	log.FromContext(ctx).Info("Connecting to AutoPi", "target", target)
 	conn, err := grpc.DialContext(ctx, target, gc.metadata.GetOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("could not connect to gRPC server: %v", err)
 	}
-	return pb.NewRemoteWorkProcessorServiceClient(conn), nil
+	return conn, nil
 }
 
-func (gc *RemoteWorkProcessorGrpcClient) startSession(rpcClient pb.RemoteWorkProcessorServiceClient, ctx context.Context) error {
-	log.Println("Creating gRPC stream session...")
+func (gc *RemoteWorkProcessorGrpcClient) startSession(rpcClient pb.RemoteWorkProcessorServiceClient,
+	ctx context.Context) (pb.RemoteWorkProcessorService_SessionClient, error) {
+	log.FromContext(ctx).V(1).Info("Creating gRPC stream session...")
 	stream, err := rpcClient.Session(ctx)
 	if err != nil {
-		return fmt.Errorf("could not start a session with the server: %v", err)
+		return nil, fmt.Errorf("could not start a session with the server: %v", err)
 	}
-
-	gc.stream = stream
-	go gc.runHeartbeat()
-	return nil
+	return stream, nil
 }
 
-func (gc *RemoteWorkProcessorGrpcClient) runHeartbeat() {
+func (gc *RemoteWorkProcessorGrpcClient) runHeartbeat(ctx context.Context) {
 	t := time.NewTicker(30 * time.Second)
 	defer t.Stop()
 
@@ -138,10 +226,14 @@ Loop:
 				},
 			}
 			if err := gc.Send(msg); err != nil {
-				log.Printf("Error sending heartbeat: %v\n", err)
+				log.FromContext(ctx).Error(err, "Error sending heartbeat")
+				metrics.GrpcHeartbeatFailuresTotal.Inc()
+				gc.Lock()
+				gc.heartbeatErr = err
+				gc.Unlock()
 				break Loop
 			}
-		case <-gc.context.Done():
+		case <-ctx.Done():
 			break Loop
 		}
 	}
@@ -150,4 +242,6 @@ Loop:
 func (gc *RemoteWorkProcessorGrpcClient) closeConn() {
 	gc.stream.CloseSend()
 	gc.cancelCtx()
+	gc.conn.Close()
+	gc.stream = nil
 }
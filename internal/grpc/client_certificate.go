@@ -0,0 +1,189 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/SAP/remote-work-processor/internal/metrics"
+	"github.com/SAP/remote-work-processor/internal/utils"
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	certificateExpiryWarningPeriod = 7 * 24 * time.Hour
+	certificateExpiryCheckInterval = time.Hour
+	// a rotation of a mounted secret consists of several file system events, the certificate is reloaded once they settle
+	certificateReloadDelay = time.Second
+)
+
+// ClientCertificate serves the client certificate for the mTLS handshake with the server. A certificate mounted
+// from files is reloaded whenever they change, e.g. when cert-manager has rotated the secret, so that new connections
+// use it without restarting the process. A certificate which cannot be loaded does not replace the previous one.
+type ClientCertificate struct {
+	sync.RWMutex
+	load     func() (tls.Certificate, error)
+	cert     *tls.Certificate
+	notAfter time.Time
+	err      error
+	logger   logr.Logger
+}
+
+// NewFileClientCertificate loads the certificate from the given files and reloads it on changes
+// in their directory, until the context is cancelled.
+func NewFileClientCertificate(ctx context.Context, dir, certFile, keyFile string) *ClientCertificate {
+	c := &ClientCertificate{
+		load: func() (tls.Certificate, error) {
+			return tls.LoadX509KeyPair(dir+certFile, dir+keyFile)
+		},
+		logger: log.FromContext(ctx),
+	}
+
+	// the watch starts before the initial load, so that no change in between is missed
+	watcher := c.watchDir(dir)
+	c.reload()
+	go c.run(ctx, watcher)
+	return c
+}
+
+// NewEnvClientCertificate loads the base64 encoded certificate chain and private key from the environment.
+func NewEnvClientCertificate(ctx context.Context, certChainEnv, privateKeyEnv string) *ClientCertificate {
+	c := &ClientCertificate{
+		logger: log.FromContext(ctx),
+	}
+	c.load = func() (tls.Certificate, error) {
+		certChain, err := base64.StdEncoding.DecodeString(utils.GetRequiredEnv(certChainEnv))
+		if err != nil {
+			return tls.Certificate{}, fmt.Errorf("could not decode certificate chain from environment: %v", err)
+		}
+
+		privateKey, err := base64.StdEncoding.DecodeString(utils.GetRequiredEnv(privateKeyEnv))
+		if err != nil {
+			return tls.Certificate{}, fmt.Errorf("could not decode private key from environment: %v", err)
+		}
+		return tls.X509KeyPair(certChain, privateKey)
+	}
+
+	c.reload()
+	go c.run(ctx, nil)
+	return c
+}
+
+// GetClientCertificate implements tls.Config.GetClientCertificate.
+func (c *ClientCertificate) GetClientCertificate(_ *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	if c.cert == nil {
+		return nil, fmt.Errorf("no client certificate available: %v", c.err)
+	}
+	return c.cert, nil
+}
+
+func (c *ClientCertificate) reload() {
+	cert, err := c.load()
+	var leaf *x509.Certificate
+	if err == nil {
+		leaf, err = parseLeaf(cert)
+	}
+	if err != nil {
+		c.logger.Error(err, "Could not load client certificate")
+		c.Lock()
+		c.err = err
+		c.Unlock()
+		return
+	}
+
+	c.Lock()
+	c.cert = &cert
+	c.notAfter = leaf.NotAfter
+	c.err = nil
+	c.Unlock()
+
+	metrics.ClientCertificateExpiry.Set(float64(leaf.NotAfter.Unix()))
+	c.logger.Info("Loaded client certificate", "subject", leaf.Subject.String(), "not_after", leaf.NotAfter)
+	c.checkExpiry()
+}
+
+func (c *ClientCertificate) checkExpiry() {
+	c.RLock()
+	notAfter := c.notAfter
+	c.RUnlock()
+
+	if notAfter.IsZero() {
+		return
+	}
+
+	remaining := time.Until(notAfter)
+	switch {
+	case remaining <= 0:
+		c.logger.Error(errors.New("client certificate has expired"), "The server will reject new connections until the certificate is renewed",
+			"not_after", notAfter)
+	case remaining <= certificateExpiryWarningPeriod:
+		c.logger.Info("WARNING: client certificate is about to expire", "not_after", notAfter,
+			"expires_in", remaining.Truncate(time.Minute).String())
+	}
+}
+
+// watchDir returns nil if the directory cannot be watched, the certificate is not reloaded then.
+func (c *ClientCertificate) watchDir(dir string) *fsnotify.Watcher {
+	watcher, err := fsnotify.NewWatcher()
+	if err == nil {
+		if err = watcher.Add(dir); err != nil {
+			watcher.Close()
+		}
+	}
+	if err != nil {
+		c.logger.Error(err, "Could not watch client certificate, it will not be reloaded on changes", "dir", dir)
+		return nil
+	}
+	return watcher
+}
+
+// run reloads the certificate on the changes reported by the watcher, if any, and periodically checks its expiry.
+func (c *ClientCertificate) run(ctx context.Context, watcher *fsnotify.Watcher) {
+	var events chan fsnotify.Event
+	var errs chan error
+	if watcher != nil {
+		defer watcher.Close()
+		events, errs = watcher.Events, watcher.Errors
+	}
+
+	expiryTicker := time.NewTicker(certificateExpiryCheckInterval)
+	defer expiryTicker.Stop()
+
+	var reload <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-events:
+			c.logger.V(1).Info("Client certificate changed", "event", event.String())
+			reload = time.After(certificateReloadDelay)
+		case err := <-errs:
+			c.logger.Error(err, "Error while watching client certificate")
+		case <-reload:
+			reload = nil
+			c.reload()
+		case <-expiryTicker.C:
+			c.checkExpiry()
+		}
+	}
+}
+
+func parseLeaf(cert tls.Certificate) (*x509.Certificate, error) {
+	if cert.Leaf != nil {
+		return cert.Leaf, nil
+	}
+	if len(cert.Certificate) == 0 {
+		return nil, errors.New("client certificate chain is empty")
+	}
+	return x509.ParseCertificate(cert.Certificate[0])
+}
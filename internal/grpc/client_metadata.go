@@ -1,11 +1,9 @@
 package grpc
 
 import (
+	"context"
 	"crypto/tls"
-	"encoding/base64"
-	"github.com/SAP/remote-work-processor/internal/utils"
 	"google.golang.org/grpc/credentials/insecure"
-	"log"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -33,10 +31,17 @@ func NewClientMetadata(host string, port string, isStandaloneMode bool) *ClientM
 	}
 }
 
-func (cm *ClientMetadata) WithClientCertificate() *ClientMetadata {
-	cert := cm.getClientCert()
+// WithClientCertificate authenticates the connections with the client certificate, which is taken from the environment
+// in standalone mode and from the mounted secret otherwise. Each connection uses the latest version of the certificate.
+func (cm *ClientMetadata) WithClientCertificate(ctx context.Context) *ClientMetadata {
+	var cert *ClientCertificate
+	if cm.standaloneMode {
+		cert = NewEnvClientCertificate(ctx, "RWP_CERT_CHAIN", "RWP_PRIVATE_KEY")
+	} else {
+		cert = NewFileClientCertificate(ctx, CERTIFICATE_MOUTH_PATH, CERTIFICATE_KEY, PRIVATE_KEY)
+	}
 	config := &tls.Config{
-		Certificates: []tls.Certificate{cert},
+		GetClientCertificate: cert.GetClientCertificate,
 	}
 	cm.options = append(cm.options, grpc.WithTransportCredentials(credentials.NewTLS(config)))
 	return cm
@@ -67,32 +72,3 @@ func (cm *ClientMetadata) GetBinaryVersion() string {
 func (cm *ClientMetadata) GetOptions() []grpc.DialOption {
 	return cm.options
 }
-
-func (cm *ClientMetadata) getClientCert() tls.Certificate {
-	if cm.standaloneMode {
-		certChain := utils.GetRequiredEnv("RWP_CERT_CHAIN")
-		privateKey := utils.GetRequiredEnv("RWP_PRIVATE_KEY")
-
-		certChainBytes, err := base64.StdEncoding.DecodeString(certChain)
-		if err != nil {
-			log.Fatalln("Could not decode certificate chain from environment:", err)
-		}
-
-		privateKeyBytes, err := base64.StdEncoding.DecodeString(privateKey)
-		if err != nil {
-			log.Fatalln("Could not decode private key from environment:", err)
-		}
-
-		cert, err := tls.X509KeyPair(certChainBytes, privateKeyBytes)
-		if err != nil {
-			log.Fatalln("Could not load client certificate from environment:", err)
-		}
-		return cert
-	} else {
-		cert, err := tls.LoadX509KeyPair(CERTIFICATE_MOUTH_PATH+CERTIFICATE_KEY, CERTIFICATE_MOUTH_PATH+PRIVATE_KEY)
-		if err != nil {
-			log.Fatalln("Could not load client certificate from files:", err)
-		}
-		return cert
-	}
-}
@@ -2,9 +2,9 @@ package processors
 
 import (
 	"context"
-	"log"
 
 	pb "github.com/SAP/remote-work-processor/build/proto/generated"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 type DisableProcessor struct {
@@ -17,8 +17,8 @@ func NewDisableProcessor(disableFunc func()) DisableProcessor {
 	}
 }
 
-func (p DisableProcessor) Process(_ context.Context) (*pb.ClientMessage, error) {
-	log.Println("Disabling work processor...")
+func (p DisableProcessor) Process(ctx context.Context) (*pb.ClientMessage, error) {
+	log.FromContext(ctx).Info("Disabling work processor...")
 
 	p.disableFunc()
 
@@ -0,0 +1,109 @@
+package processors
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	pb "github.com/SAP/remote-work-processor/build/proto/generated"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type MessageSender interface {
+	Send(msg *pb.ClientMessage) error
+}
+
+// Dispatcher executes remote tasks on a bounded number of workers, while all other (control) messages
+// are processed right away, so that they never wait behind slow tasks. Tasks received while the queue of tasks
+// waiting for a worker is full are not executed, but reported as retryable failures.
+// Tasks are executed with the tasks context rather than the one of the session they have been received on:
+// once the tasks context is cancelled, they are aborted and still get to report their result.
+type Dispatcher struct {
+	sync.Mutex
+	tasksCtx context.Context
+	sender   MessageSender
+	workers  chan struct{}
+	// the tasks running or waiting for a worker, each of them on its own goroutine
+	tasks chan struct{}
+	wg    sync.WaitGroup
+}
+
+func NewDispatcher(tasksCtx context.Context, sender MessageSender, workers uint, queueSize uint) *Dispatcher {
+	if workers == 0 {
+		workers = 1
+	}
+	return &Dispatcher{
+		tasksCtx: tasksCtx,
+		sender:   sender,
+		workers:  make(chan struct{}, workers),
+		tasks:    make(chan struct{}, workers+queueSize),
+	}
+}
+
+func (d *Dispatcher) Dispatch(ctx context.Context, p Processor) error {
+	if task, isTask := p.(RemoteTaskProcessor); isTask {
+		d.Lock()
+		defer d.Unlock()
+
+		if d.tasksCtx.Err() != nil {
+			// tasks received after the cancellation are reported right away, without being executed
+			ctx = d.tasksCtx
+		} else {
+			select {
+			case d.tasks <- struct{}{}:
+				d.wg.Add(1)
+				go d.runTask(p)
+				return nil
+			default:
+				log.FromContext(ctx).Info("Task queue is full, rejecting task...", "queued_tasks", len(d.tasks))
+				return d.send(task.Reject("task queue of the Remote Work Processor is full"))
+			}
+		}
+	}
+
+	msg, err := p.Process(ctx)
+	if err != nil {
+		return fmt.Errorf("error processing operation: %v", err)
+	}
+	return d.send(msg)
+}
+
+// Wait blocks until all dispatched tasks have finished. Once the tasks context has been cancelled,
+// no more tasks are added in the meantime.
+func (d *Dispatcher) Wait() {
+	// ensures that a concurrent Dispatch has either added its task or seen the cancellation
+	d.Lock()
+	d.Unlock()
+
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) runTask(p Processor) {
+	defer d.wg.Done()
+	defer func() { <-d.tasks }()
+
+	select {
+	case d.workers <- struct{}{}:
+		defer func() { <-d.workers }()
+	case <-d.tasksCtx.Done():
+		// the task does not need a worker, it is only reported as cancelled
+	}
+
+	msg, err := p.Process(d.tasksCtx)
+	if err != nil {
+		log.FromContext(d.tasksCtx).Error(err, "error processing operation")
+		return
+	}
+
+	// a broken session is detected and reestablished by the receiving side
+	if err = d.send(msg); err != nil {
+		log.FromContext(d.tasksCtx).Error(err, "could not send task execution result")
+	}
+}
+
+func (d *Dispatcher) send(msg *pb.ClientMessage) error {
+	if msg == nil {
+		return nil
+	}
+	return d.sender.Send(msg)
+}
@@ -2,9 +2,9 @@ package processors
 
 import (
 	"context"
-	"log"
 
 	pb "github.com/SAP/remote-work-processor/build/proto/generated"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 type EnableProcessor struct {
@@ -17,8 +17,8 @@ func NewEnableProcessor(enableFunc func()) EnableProcessor {
 	}
 }
 
-func (p EnableProcessor) Process(_ context.Context) (*pb.ClientMessage, error) {
-	log.Println("Enabling work processor...")
+func (p EnableProcessor) Process(ctx context.Context) (*pb.ClientMessage, error) {
+	log.FromContext(ctx).Info("Enabling work processor...")
 
 	p.enableFunc()
 
@@ -0,0 +1,32 @@
+package processors
+
+import (
+	"context"
+
+	pb "github.com/SAP/remote-work-processor/build/proto/generated"
+	"github.com/SAP/remote-work-processor/internal/kubernetes/engine"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type NextEventProcessor struct {
+	op     *pb.ServerMessage_NextEventRequest
+	engine engine.ManagerEngine
+}
+
+func NewNextEventProcessor(op *pb.ServerMessage_NextEventRequest, engine engine.ManagerEngine) NextEventProcessor {
+	return NextEventProcessor{
+		op:     op,
+		engine: engine,
+	}
+}
+
+func (p NextEventProcessor) Process(ctx context.Context) (*pb.ClientMessage, error) {
+	if p.engine == nil {
+		log.FromContext(ctx).Info("Unable to process next event request: Remote Worker is running in standalone mode.")
+		return nil, nil
+	}
+
+	// the next queued reconciliation event, if any, is sent by the event queue itself
+	p.engine.ReleaseNextEvent(p.op.NextEventRequest)
+	return nil, nil
+}
@@ -0,0 +1,39 @@
+package processors
+
+import (
+	"context"
+
+	pb "github.com/SAP/remote-work-processor/build/proto/generated"
+	"github.com/SAP/remote-work-processor/internal/kubernetes/engine"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type ReconciliationResultProcessor struct {
+	op     *pb.ServerMessage_ReconciliationResult
+	engine engine.ManagerEngine
+}
+
+func NewReconciliationResultProcessor(op *pb.ServerMessage_ReconciliationResult, engine engine.ManagerEngine) ReconciliationResultProcessor {
+	return ReconciliationResultProcessor{
+		op:     op,
+		engine: engine,
+	}
+}
+
+func (p ReconciliationResultProcessor) Process(ctx context.Context) (*pb.ClientMessage, error) {
+	result := p.op.ReconciliationResult
+	logger := log.FromContext(ctx).WithValues("reconciler", result.GetReconcilerName(),
+		"name", result.GetRequest().GetResourceName(), "namespace", result.GetRequest().GetResourceNamespace())
+	if p.engine == nil {
+		logger.Info("Unable to process reconciliation result: Remote Worker is running in standalone mode.")
+		return nil, nil
+	}
+
+	// the result is not acknowledged, a failure must not end the session
+	if err := p.engine.SetReconciliationResult(ctx, result); err != nil {
+		logger.Error(err, "Could not set reconciliation result", "condition_type", result.GetConditionType())
+		return nil, nil
+	}
+	logger.V(1).Info("Reconciliation result set", "condition_type", result.GetConditionType(), "status", result.GetStatus())
+	return nil, nil
+}
@@ -0,0 +1,39 @@
+package processors
+
+import (
+	"context"
+
+	pb "github.com/SAP/remote-work-processor/build/proto/generated"
+	"github.com/SAP/remote-work-processor/internal/kubernetes/engine"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type ReleaseFinalizerProcessor struct {
+	op     *pb.ServerMessage_ReleaseFinalizer
+	engine engine.ManagerEngine
+}
+
+func NewReleaseFinalizerProcessor(op *pb.ServerMessage_ReleaseFinalizer, engine engine.ManagerEngine) ReleaseFinalizerProcessor {
+	return ReleaseFinalizerProcessor{
+		op:     op,
+		engine: engine,
+	}
+}
+
+func (p ReleaseFinalizerProcessor) Process(ctx context.Context) (*pb.ClientMessage, error) {
+	req := p.op.ReleaseFinalizer
+	logger := log.FromContext(ctx).WithValues("reconciler", req.GetReconcilerName(),
+		"name", req.GetRequest().GetResourceName(), "namespace", req.GetRequest().GetResourceNamespace())
+	if p.engine == nil {
+		logger.Info("Unable to release finalizer: Remote Worker is running in standalone mode.")
+		return nil, nil
+	}
+
+	// the release is not acknowledged, a failure must not end the session
+	if err := p.engine.ReleaseFinalizer(ctx, req); err != nil {
+		logger.Error(err, "Could not release finalizer")
+		return nil, nil
+	}
+	logger.Info("Finalizer released")
+	return nil, nil
+}
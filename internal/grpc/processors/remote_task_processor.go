@@ -2,47 +2,147 @@ package processors
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"time"
 
 	pb "github.com/SAP/remote-work-processor/build/proto/generated"
 	"github.com/SAP/remote-work-processor/internal/executors"
-	"github.com/SAP/remote-work-processor/internal/executors/factory"
+	"github.com/SAP/remote-work-processor/internal/metrics"
+	"github.com/SAP/remote-work-processor/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"google.golang.org/protobuf/types/known/wrapperspb"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 type RemoteTaskProcessor struct {
 	req       *pb.TaskExecutionRequestMessage
+	registry  *executors.Registry
 	isEnabled func() bool
 }
 
-func NewRemoteTaskProcessor(req *pb.ServerMessage_TaskExecutionRequest, isEnabled func() bool) RemoteTaskProcessor {
+func NewRemoteTaskProcessor(req *pb.ServerMessage_TaskExecutionRequest, registry *executors.Registry,
+	isEnabled func() bool) RemoteTaskProcessor {
 	return RemoteTaskProcessor{
 		req:       req.TaskExecutionRequest,
+		registry:  registry,
 		isEnabled: isEnabled,
 	}
 }
 
-func (p RemoteTaskProcessor) Process(_ context.Context) (*pb.ClientMessage, error) {
+// Process executes the task until it completes or the context is cancelled, in which case the task
+// is reported as retryable. The context is bounded by the deadline of the task, if the server has set one.
+func (p RemoteTaskProcessor) Process(taskCtx context.Context) (*pb.ClientMessage, error) {
+	start := time.Now()
+	if deadline := p.req.GetDeadline(); deadline != nil {
+		var cancel context.CancelFunc
+		taskCtx, cancel = context.WithDeadline(taskCtx, deadline.AsTime())
+		defer cancel()
+	}
+
+	taskCtx = tracing.WithExecution(taskCtx, p.req.GetExecutionId(), p.req.GetExecutionVersion())
+	taskCtx, span := tracing.Start(taskCtx, "RemoteTaskProcessor.Process",
+		attribute.String("task_type", p.req.GetType().String()),
+		attribute.Int("task_version", int(p.req.GetTaskVersion())),
+	)
+	// everything logged on behalf of the task, including by the executors, can be correlated with the execution
+	taskCtx = log.IntoContext(taskCtx, log.FromContext(taskCtx).WithValues(
+		"execution_id", p.req.GetExecutionId(),
+		"execution_version", p.req.GetExecutionVersion(),
+		"task_type", p.req.GetType().String(),
+	))
+
+	ctx := executors.NewExecutorContext(p.req.GetInput(), p.req.Store,
+		executors.TaskVersion(p.req.GetTaskVersion()),
+		executors.Sensitive(p.req.GetIsSensitive()),
+		executors.TaskContext(taskCtx),
+	)
+	res := p.execute(ctx, taskCtx)
+	res.Error = ctx.Redact(res.Error)
+
+	taskType, state := p.req.GetType().String(), res.Status.String()
+	metrics.TasksTotal.WithLabelValues(taskType, state).Inc()
+	metrics.TaskDuration.WithLabelValues(taskType, state).Observe(time.Since(start).Seconds())
+
+	span.SetAttributes(attribute.String("task_state", state))
+	if res.Status != pb.TaskExecutionResponseMessage_TASK_STATE_COMPLETED {
+		span.SetStatus(codes.Error, res.Error)
+	}
+	span.End()
+
+	return &pb.ClientMessage{
+		Body: buildResult(ctx, p.req, res),
+	}, nil
+}
+
+// Reject reports the task as a retryable failure without executing it, e.g. when there is no capacity left.
+func (p RemoteTaskProcessor) Reject(reason string) *pb.ClientMessage {
 	ctx := executors.NewExecutorContext(p.req.GetInput(), p.req.Store)
+	res := executors.NewExecutorResult(
+		executors.Status(pb.TaskExecutionResponseMessage_TASK_STATE_FAILED_RETRYABLE),
+		executors.ErrorString(reason),
+	)
+	metrics.TasksTotal.WithLabelValues(p.req.GetType().String(), res.Status.String()).Inc()
+	return &pb.ClientMessage{
+		Body: buildResult(ctx, p.req, res),
+	}
+}
 
+func (p RemoteTaskProcessor) execute(ctx executors.Context, taskCtx context.Context) *executors.ExecutorResult {
+	logger := log.FromContext(taskCtx)
 	if !p.isEnabled() {
-		log.Println("Unable to process remote task. Remote Worker is disabled...")
-		return &pb.ClientMessage{
-			Body: buildResult(ctx, p.req, executors.NewExecutorResult(
-				executors.Status(pb.TaskExecutionResponseMessage_TASK_STATE_FAILED_NON_CHARGEABLE),
-				executors.Error(fmt.Errorf("unable to process remote task. Remote Worker is disabled")),
-			)),
-		}, nil
+		logger.Info("Unable to process remote task. Remote Worker is disabled...")
+		return executors.NewExecutorResult(
+			executors.Status(pb.TaskExecutionResponseMessage_TASK_STATE_FAILED_NON_CHARGEABLE),
+			executors.Error(fmt.Errorf("unable to process remote task. Remote Worker is disabled")),
+		)
+	}
+
+	if p.req.GetIsSensitive() {
+		logger.Info("Processing sensitive Task. Secrets are redacted from the logs and errors...")
+		deactivate := executors.ActivateLogRedaction(executors.NewRedactor(p.req.GetInput()))
+		defer deactivate()
 	}
 
-	log.Println("Processing Task...")
-	executor := factory.CreateExecutor(p.req.GetType())
+	logger.Info("Processing Task...", "task_version", ctx.GetTaskVersion())
+	executor, err := p.registry.Create(p.req.GetType(), ctx.GetTaskVersion())
+	if err != nil {
+		logger.Error(err, "Could not create executor")
+		state := pb.TaskExecutionResponseMessage_TASK_STATE_FAILED_NON_CHARGEABLE
+		var nonRetryable *executors.NonRetryableError
+		if errors.As(err, &nonRetryable) {
+			state = pb.TaskExecutionResponseMessage_TASK_STATE_FAILED_NON_RETRYABLE
+		}
+		return executors.NewExecutorResult(
+			executors.Status(state),
+			executors.Error(err),
+		)
+	}
+
+	if err = taskCtx.Err(); err != nil {
+		logger.Info("Task cancelled before its execution started")
+		return cancelledResult(err)
+	}
 
 	res := executor.Execute(ctx)
-	return &pb.ClientMessage{
-		Body: buildResult(ctx, p.req, res),
-	}, nil
+	if err = taskCtx.Err(); err != nil && res.Status != pb.TaskExecutionResponseMessage_TASK_STATE_COMPLETED {
+		// whatever made the task fail, it has been aborted
+		logger.Info("Task cancelled during its execution")
+		return cancelledResult(err)
+	}
+	return res
+}
+
+func cancelledResult(cause error) *executors.ExecutorResult {
+	msg := "task execution cancelled"
+	if errors.Is(cause, context.DeadlineExceeded) {
+		msg = "task execution cancelled: deadline exceeded"
+	}
+	return executors.NewExecutorResult(
+		executors.Status(pb.TaskExecutionResponseMessage_TASK_STATE_FAILED_RETRYABLE),
+		executors.ErrorString(msg),
+	)
 }
 
 func buildResult(ctx executors.Context, req *pb.TaskExecutionRequestMessage, res *executors.ExecutorResult) *pb.ClientMessage_TaskExecutionResponse {
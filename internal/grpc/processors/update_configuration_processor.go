@@ -2,10 +2,13 @@ package processors
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"os"
 
 	pb "github.com/SAP/remote-work-processor/build/proto/generated"
 	"github.com/SAP/remote-work-processor/internal/kubernetes/engine"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 type UpdateWatchConfigurationProcessor struct {
@@ -26,29 +29,42 @@ func NewUpdateWatchConfigurationProcessor(op *pb.ServerMessage_UpdateConfigReque
 }
 
 func (p UpdateWatchConfigurationProcessor) Process(ctx context.Context) (*pb.ClientMessage, error) {
+	logger := log.FromContext(ctx).WithValues("config_version", p.op.UpdateConfigRequest.GetConfigVersion())
 	if !p.isEnabled() {
-		log.Println("Unable to process watch config: Remote Worker is disabled.")
+		logger.Info("Unable to process watch config: Remote Worker is disabled.")
 		// this corner case can't happen unless there is a proxy between the AutoPi and the RWP
 		// because the AutoPi won't send any messages to disabled Operators
 		return nil, nil
 	}
 
-	if len(p.op.UpdateConfigRequest.Resources) == 0 {
-		// handle session auto-config
+	if len(p.op.UpdateConfigRequest.Resources) == 0 && (p.engine == nil || !p.engine.IsRunning()) {
+		// handle session auto-config, while a running watch is updated to stop the controllers of all reconcilers
 		return &pb.ClientMessage{Body: p.getConfirmUpdateMessage()}, nil
 	}
 
 	if p.engine == nil {
-		log.Println("Unable to process watch config: Remote Worker is running in standalone mode.")
+		logger.Info("Unable to process watch config: Remote Worker is running in standalone mode.")
 		// this corner case can't happen unless there is a proxy between the AutoPi and the RWP
 		// because the AutoPi won't send UpdateConfig messages to Standalone Operators
 		return nil, nil
 	}
 
-	if p.engine.IsRunning() {
-		log.Println("Stopping Manager...")
-		p.engine.Stop()
-		<-p.drainChan
+	if p.engine.IsRunning() && p.engine.GetConfigVersion() == p.op.UpdateConfigRequest.GetConfigVersion() {
+		// the server re-sends the current watch config after the session has been reestablished
+		logger.Info("Watch config version is already applied")
+		return &pb.ClientMessage{Body: p.getConfirmUpdateMessage()}, nil
+	}
+
+	if errs := p.engine.ValidateWatchConfiguration(p.op.UpdateConfigRequest); len(errs) > 0 {
+		// the previous watch config, if any, keeps being watched
+		logger.Error(errors.New("invalid watch config"), "Rejecting watch config", "errors", fmt.Sprint(errs))
+		return &pb.ClientMessage{Body: p.getRejectUpdateMessage(errs)}, nil
+	}
+
+	logger.Info("New watch config received...")
+	if p.engine.SetWatchConfiguration(p.op.UpdateConfigRequest) {
+		// the running manager replaces only the controllers of the reconcilers which have changed
+		return &pb.ClientMessage{Body: p.getConfirmUpdateMessage()}, nil
 	}
 
 	go func() {
@@ -58,11 +74,9 @@ func (p UpdateWatchConfigurationProcessor) Process(ctx context.Context) (*pb.Cli
 		default:
 		}
 
-		log.Println("New watch config received...")
-		p.engine.SetWatchConfiguration(p.op.UpdateConfigRequest)
-
 		if err := p.engine.WatchResources(ctx, p.isEnabled); err != nil {
-			log.Fatalln("failed to watch resources:", err)
+			logger.Error(err, "failed to watch resources")
+			os.Exit(1)
 		}
 		p.drainChan <- struct{}{}
 	}()
@@ -77,3 +91,16 @@ func (p UpdateWatchConfigurationProcessor) getConfirmUpdateMessage() *pb.ClientM
 		},
 	}
 }
+
+func (p UpdateWatchConfigurationProcessor) getRejectUpdateMessage(errs map[string]error) *pb.ClientMessage_RejectConfigUpdate {
+	messages := make(map[string]string, len(errs))
+	for reconciler, err := range errs {
+		messages[reconciler] = err.Error()
+	}
+	return &pb.ClientMessage_RejectConfigUpdate{
+		RejectConfigUpdate: &pb.RejectConfigUpdateMessage{
+			ConfigVersion: p.op.UpdateConfigRequest.GetConfigVersion(),
+			Errors:        messages,
+		},
+	}
+}
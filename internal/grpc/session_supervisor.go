@@ -0,0 +1,139 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/SAP/remote-work-processor/build/proto/generated"
+	"github.com/SAP/remote-work-processor/internal/metrics"
+	"github.com/SAP/remote-work-processor/internal/utils"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// healthySessionDuration is how long a session has to stay up to restore the attempt budget, so that a server
+// dropping every session right away does not get reconnected to over and over at the shortest interval.
+const healthySessionDuration = time.Minute
+
+type MessageHandler func(ctx context.Context, msg *pb.ServerMessage) error
+
+// SessionSupervisor keeps a session with the server alive. Whenever the session breaks down, including when the server
+// closes it, it is re-established with backoff, until the attempt budget is exhausted. The budget is restored once
+// a session has proven to be healthy by staying up for healthySessionDuration.
+type SessionSupervisor struct {
+	client           *RemoteWorkProcessorGrpcClient
+	sessionID        string
+	retryConfig      *utils.RetryConfig
+	sessionListeners []func()
+	// when the current step of the loop has started, zero while it waits for the server or for the next attempt
+	busySince atomic.Int64
+}
+
+func NewSessionSupervisor(client *RemoteWorkProcessorGrpcClient, sessionID string, retryConfig *utils.RetryConfig) *SessionSupervisor {
+	return &SessionSupervisor{
+		client:      client,
+		sessionID:   sessionID,
+		retryConfig: retryConfig,
+	}
+}
+
+// OnSessionStarted registers a listener invoked every time a new session has been established.
+func (s *SessionSupervisor) OnSessionStarted(listener func()) *SessionSupervisor {
+	s.sessionListeners = append(s.sessionListeners, listener)
+	return s
+}
+
+// Run blocks until the context is cancelled or the attempt budget is exhausted.
+func (s *SessionSupervisor) Run(ctx context.Context, handle MessageHandler) error {
+	defer s.idle()
+	for {
+		s.busy()
+		err := s.client.InitSession(ctx, s.sessionID)
+		if err == nil {
+			started := time.Now()
+			s.notifySessionStarted(ctx)
+			if err = s.serve(ctx, handle); err == nil {
+				return nil
+			}
+			if time.Since(started) >= healthySessionDuration {
+				s.retryConfig.Reset()
+			}
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if !s.retryConfig.CanRetry() {
+			return fmt.Errorf("could not reestablish the session with the server: %v", err)
+		}
+
+		s.idle()
+		if !utils.Retry(ctx, s.retryConfig, err) {
+			return nil
+		}
+		metrics.GrpcReconnectsTotal.Inc()
+	}
+}
+
+func (s *SessionSupervisor) serve(ctx context.Context, handle MessageHandler) error {
+	defer s.client.CloseSession()
+
+	for {
+		s.idle()
+		msg, err := s.client.ReceiveMsg()
+		s.busy()
+		if err != nil {
+			return err
+		}
+		if msg == nil {
+			// the session has been closed due to the context cancellation
+			return nil
+		}
+
+		if err = handle(ctx, msg); err != nil {
+			return err
+		}
+	}
+}
+
+// BusyFor returns how long the supervisor has been processing its current step, e.g. establishing the session
+// or handling a message, and 0 while it waits for the server or for the next attempt. A long-running step
+// means that the loop is stuck.
+func (s *SessionSupervisor) BusyFor() time.Duration {
+	since := s.busySince.Load()
+	if since == 0 {
+		return 0
+	}
+	return time.Since(time.Unix(0, since))
+}
+
+func (s *SessionSupervisor) busy() {
+	s.busySince.Store(time.Now().UnixNano())
+}
+
+func (s *SessionSupervisor) idle() {
+	s.busySince.Store(0)
+}
+
+func (s *SessionSupervisor) notifySessionStarted(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	if version := s.client.GetConfirmedConfigVersion(); version != "" {
+		logger.Info("Re-sending confirmation for watch config", "config_version", version)
+		msg := &pb.ClientMessage{
+			Body: &pb.ClientMessage_ConfirmConfigUpdate{
+				ConfirmConfigUpdate: &pb.ConfirmConfigUpdateMessage{
+					ConfigVersion: version,
+				},
+			},
+		}
+		if err := s.client.Send(msg); err != nil {
+			logger.Error(err, "Could not re-send watch config confirmation")
+		}
+	}
+
+	for _, listener := range s.sessionListeners {
+		listener()
+	}
+}
@@ -0,0 +1,144 @@
+package health
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	pb "github.com/SAP/remote-work-processor/build/proto/generated"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// stuckThreshold is how long the session loop may process a single step, e.g. a control message, before
+// the Remote Work Processor is considered stuck and is restarted by the liveness probe.
+const stuckThreshold = 5 * time.Minute
+
+// SessionLoop is the loop receiving and dispatching the messages of the server.
+type SessionLoop interface {
+	BusyFor() time.Duration
+}
+
+type SessionState interface {
+	IsSessionActive() bool
+	GetHeartbeatError() error
+}
+
+type WatchConfigState interface {
+	GetConfigVersion() string
+	GetWatchedResources() map[string]*pb.Resource
+	IsRunning() bool
+	IsLeader() bool
+}
+
+// Probes gather the state the health server reports. WatchConfig is nil in standalone mode.
+type Probes struct {
+	Loop        SessionLoop
+	Session     SessionState
+	WatchConfig WatchConfigState
+	IsEnabled   func() bool
+}
+
+// LivenessChecks fail while the session loop is stuck, whatever the state of the session, which is up
+// to the readiness checks: a session which is down is reestablished by the loop.
+func (p Probes) LivenessChecks() map[string]healthz.Checker {
+	return map[string]healthz.Checker{
+		"ping": healthz.Ping,
+		"session-loop": func(_ *http.Request) error {
+			if busy := p.Loop.BusyFor(); busy > stuckThreshold {
+				return fmt.Errorf("session loop stuck for %s", busy.Round(time.Second))
+			}
+			return nil
+		},
+	}
+}
+
+// ReadinessChecks make the Remote Work Processor unready while it cannot process any work:
+// the session with the server is down, its heartbeats are failing or the server has disabled it.
+func (p Probes) ReadinessChecks() map[string]healthz.Checker {
+	return map[string]healthz.Checker{
+		"session": func(_ *http.Request) error {
+			if !p.Session.IsSessionActive() {
+				return errors.New("no session with the server")
+			}
+			return nil
+		},
+		"heartbeat": func(_ *http.Request) error {
+			return p.Session.GetHeartbeatError()
+		},
+		"enabled": func(_ *http.Request) error {
+			if !p.IsEnabled() {
+				return errors.New("disabled by the server")
+			}
+			return nil
+		},
+	}
+}
+
+type Status struct {
+	Enabled       bool         `json:"enabled"`
+	SessionActive bool         `json:"sessionActive"`
+	ConfigVersion string       `json:"configVersion,omitempty"`
+	Leader        bool         `json:"leader"`
+	Controllers   []Controller `json:"controllers"`
+}
+
+type Controller struct {
+	Reconciler                    string   `json:"reconciler"`
+	ApiVersion                    string   `json:"apiVersion"`
+	Kind                          string   `json:"kind"`
+	LabelSelectors                []string `json:"labelSelectors,omitempty"`
+	FieldSelectors                []string `json:"fieldSelectors,omitempty"`
+	Namespace                     string   `json:"namespace,omitempty"`
+	Namespaces                    []string `json:"namespaces,omitempty"`
+	NamespaceSelector             string   `json:"namespaceSelector,omitempty"`
+	AllNamespaces                 bool     `json:"allNamespaces,omitempty"`
+	FinalizerPolicy               string   `json:"finalizerPolicy"`
+	IgnoreStatusUpdates           bool     `json:"ignoreStatusUpdates,omitempty"`
+	IgnoreMetadataUpdates         bool     `json:"ignoreMetadataUpdates,omitempty"`
+	IgnoredPaths                  []string `json:"ignoredPaths,omitempty"`
+	ReconciliationPeriodInMinutes int32    `json:"reconciliationPeriodInMinutes"`
+}
+
+// Status returns a snapshot of the watch config and of the controllers running for it, for troubleshooting.
+// No controllers are running on replicas which are not the leader.
+func (p Probes) Status() Status {
+	s := Status{
+		Enabled:       p.IsEnabled(),
+		SessionActive: p.Session.IsSessionActive(),
+		Controllers:   []Controller{},
+	}
+	if p.WatchConfig == nil {
+		return s
+	}
+
+	s.ConfigVersion = p.WatchConfig.GetConfigVersion()
+	s.Leader = p.WatchConfig.IsLeader()
+	if !s.Leader {
+		return s
+	}
+
+	for reconciler, resource := range p.WatchConfig.GetWatchedResources() {
+		s.Controllers = append(s.Controllers, Controller{
+			Reconciler:                    reconciler,
+			ApiVersion:                    resource.GetApiVersion(),
+			Kind:                          resource.GetKind(),
+			LabelSelectors:                resource.GetLabelSelectors(),
+			FieldSelectors:                resource.GetFieldSelectors(),
+			Namespace:                     resource.GetNamespace().GetValue(),
+			Namespaces:                    resource.GetNamespaces(),
+			NamespaceSelector:             resource.GetNamespaceSelector(),
+			AllNamespaces:                 resource.GetAllNamespaces(),
+			FinalizerPolicy:               resource.GetFinalizerPolicy().String(),
+			IgnoreStatusUpdates:           resource.GetIgnoreStatusUpdates(),
+			IgnoreMetadataUpdates:         resource.GetIgnoreMetadataUpdates(),
+			IgnoredPaths:                  resource.GetIgnoredPaths(),
+			ReconciliationPeriodInMinutes: resource.GetReconciliationPeriodInMinutes(),
+		})
+	}
+	sort.Slice(s.Controllers, func(i, j int) bool {
+		return s.Controllers[i].Reconciler < s.Controllers[j].Reconciler
+	})
+	return s
+}
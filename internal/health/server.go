@@ -0,0 +1,68 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	DisabledBindAddress = "0"
+	livenessPath        = "/healthz"
+	readinessPath       = "/readyz"
+	statusPath          = "/debug/status"
+	shutdownTimeout     = 5 * time.Second
+)
+
+// Serve exposes the liveness and readiness endpoints on the given address until the context is cancelled.
+// The status endpoint is served along with them only if enabled, as it reveals the watch config to anyone
+// who can reach the address. It is a no-op if the address is DisabledBindAddress.
+func Serve(ctx context.Context, bindAddress string, probes Probes, serveStatus bool) {
+	logger := log.FromContext(ctx)
+	if bindAddress == DisabledBindAddress || bindAddress == "" {
+		logger.Info("Health server is disabled")
+		return
+	}
+
+	mux := http.NewServeMux()
+	handle(mux, livenessPath, &healthz.Handler{Checks: probes.LivenessChecks()})
+	handle(mux, readinessPath, &healthz.Handler{Checks: probes.ReadinessChecks()})
+	if serveStatus {
+		mux.HandleFunc(statusPath, func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(probes.Status()); err != nil {
+				logger.Error(err, "Could not write status")
+			}
+		})
+	}
+	server := &http.Server{
+		Addr:              bindAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	go func() {
+		logger.Info("Serving health probes", "address", bindAddress)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error(err, "Health server failed")
+		}
+	}()
+}
+
+// handle registers the handler for the path and its sub-paths, which report the result of a single check.
+func handle(mux *http.ServeMux, path string, handler http.Handler) {
+	mux.Handle(path, http.StripPrefix(path, handler))
+	mux.Handle(path+"/", http.StripPrefix(path, handler))
+}
@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"maps"
+	"slices"
+
+	pb "github.com/SAP/remote-work-processor/build/proto/generated"
+	"github.com/SAP/remote-work-processor/internal/kubernetes/selector"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+// cacheOptions restrict the objects the manager lists and watches, so that they are filtered by the API server
+// rather than by the controllers. The controllers still apply the whole selectors of their resources.
+type cacheOptions struct {
+	// empty if the cache has to span all namespaces
+	namespaces []string
+	selectors  map[schema.GroupVersionKind]cache.ObjectSelector
+	// the informers of the manager, which are started along with the first controller of their kind
+	kinds sets.Set[schema.GroupVersionKind]
+}
+
+// cacheOptionsFor restricts the cache to the namespaces of all resources, and the objects of every kind
+// to the selector requirements which all resources of the kind have in common.
+func cacheOptionsFor(resources map[string]*pb.Resource) cacheOptions {
+	return cacheOptions{
+		namespaces: cachedNamespaces(resources),
+		selectors:  cachedSelectors(resources),
+		kinds:      watchedKinds(resources),
+	}
+}
+
+// drops reports whether the options no longer watch some kinds of the other ones, whose informers would keep
+// running, and keep their objects in the cache, until the manager is restarted.
+func (o cacheOptions) drops(other cacheOptions) bool {
+	return !o.kinds.IsSuperset(other.kinds)
+}
+
+func watchedKinds(resources map[string]*pb.Resource) sets.Set[schema.GroupVersionKind] {
+	kinds := sets.New[schema.GroupVersionKind]()
+	for _, resource := range resources {
+		kinds.Insert(schema.FromAPIVersionAndKind(resource.ApiVersion, resource.Kind))
+	}
+	return kinds
+}
+
+func (o cacheOptions) equal(other cacheOptions) bool {
+	return slices.Equal(o.namespaces, other.namespaces) &&
+		maps.EqualFunc(o.selectors, other.selectors, func(s1, s2 cache.ObjectSelector) bool {
+			return objectSelectorString(s1) == objectSelectorString(s2)
+		})
+}
+
+func objectSelectorString(s cache.ObjectSelector) string {
+	var l, f string
+	if s.Label != nil {
+		l = s.Label.String()
+	}
+	if s.Field != nil {
+		f = s.Field.String()
+	}
+	return l + ";" + f
+}
+
+func cachedNamespaces(resources map[string]*pb.Resource) []string {
+	all := sets.NewString()
+	for _, resource := range resources {
+		s, err := namespaceSelectorFor(resource)
+		if err != nil {
+			// rejected along with the watch config
+			continue
+		}
+		namespaces, ok := s.CachedNamespaces()
+		if !ok {
+			return nil
+		}
+		all.Insert(namespaces...)
+	}
+	return all.List()
+}
+
+type commonRequirements struct {
+	labels map[string]labels.Requirement
+	fields map[string]fields.Requirement
+}
+
+func cachedSelectors(resources map[string]*pb.Resource) map[schema.GroupVersionKind]cache.ObjectSelector {
+	byGVK := make(map[schema.GroupVersionKind]*commonRequirements)
+	namespaceSelectors := false
+	for _, resource := range resources {
+		namespaceSelectors = namespaceSelectors || resource.GetNamespaceSelector() != ""
+		s, err := selector.NewSelector(resource.GetLabelSelectors(), resource.GetFieldSelectors())
+		if err != nil {
+			// rejected along with the watch config
+			continue
+		}
+		labelRequirements, fieldRequirements := s.NativeRequirements()
+
+		gvk := schema.FromAPIVersionAndKind(resource.ApiVersion, resource.Kind)
+		common, ok := byGVK[gvk]
+		if !ok {
+			common = &commonRequirements{
+				labels: make(map[string]labels.Requirement),
+				fields: make(map[string]fields.Requirement),
+			}
+			for _, r := range labelRequirements {
+				common.labels[labelRequirementString(r)] = r
+			}
+			for _, r := range fieldRequirements {
+				common.fields[fieldRequirementString(r)] = r
+			}
+			byGVK[gvk] = common
+			continue
+		}
+
+		retain(common.labels, labelRequirements, labelRequirementString)
+		retain(common.fields, fieldRequirements, fieldRequirementString)
+	}
+
+	if namespaceSelectors {
+		// the labels of all namespaces are looked up through the cache to match namespace selectors
+		delete(byGVK, corev1.SchemeGroupVersion.WithKind("Namespace"))
+	}
+
+	selectors := make(map[schema.GroupVersionKind]cache.ObjectSelector)
+	for gvk, common := range byGVK {
+		if len(common.labels) == 0 && len(common.fields) == 0 {
+			continue
+		}
+
+		var s cache.ObjectSelector
+		if len(common.labels) > 0 {
+			s.Label = labels.NewSelector().Add(slices.Collect(maps.Values(common.labels))...)
+		}
+		if len(common.fields) > 0 {
+			var fieldSelectors []fields.Selector
+			// sorted, so that the selectors of two watch configs can be compared
+			for _, k := range slices.Sorted(maps.Keys(common.fields)) {
+				r := common.fields[k]
+				if r.Operator == selection.NotEquals {
+					fieldSelectors = append(fieldSelectors, fields.OneTermNotEqualSelector(r.Field, r.Value))
+				} else {
+					fieldSelectors = append(fieldSelectors, fields.OneTermEqualSelector(r.Field, r.Value))
+				}
+			}
+			s.Field = fields.AndSelectors(fieldSelectors...)
+		}
+		selectors[gvk] = s
+	}
+	return selectors
+}
+
+// retain keeps only the requirements which are in the given ones as well.
+func retain[R any](common map[string]R, requirements []R, key func(R) string) {
+	keys := sets.NewString()
+	for _, r := range requirements {
+		keys.Insert(key(r))
+	}
+	for k := range common {
+		if !keys.Has(k) {
+			delete(common, k)
+		}
+	}
+}
+
+func labelRequirementString(r labels.Requirement) string {
+	return r.String()
+}
+
+func fieldRequirementString(r fields.Requirement) string {
+	return r.Field + string(r.Operator) + r.Value
+}
@@ -3,20 +3,22 @@ package controller
 import (
 	"fmt"
 	pb "github.com/SAP/remote-work-processor/build/proto/generated"
-	"github.com/SAP/remote-work-processor/internal/grpc"
 	"github.com/SAP/remote-work-processor/internal/kubernetes/selector"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
 type ControllerBuilder struct {
 	resource                      *pb.Resource
 	selector                      *selector.Selector
+	namespaceSelector             selector.NamespaceSelector
+	updateFilter                  *UpdateFilter
 	manager                       *Manager
 	reconciliationPeriodInMinutes int32
 }
@@ -37,35 +39,52 @@ func (c *ControllerBuilder) WithSelector(selector *selector.Selector) *Controlle
 	return c
 }
 
+func (c *ControllerBuilder) WithNamespaceSelector(selector selector.NamespaceSelector) *ControllerBuilder {
+	c.namespaceSelector = selector
+	return c
+}
+
+func (c *ControllerBuilder) WithUpdateFilter(filter *UpdateFilter) *ControllerBuilder {
+	c.updateFilter = filter
+	return c
+}
+
 func (c *ControllerBuilder) ManagedBy(manager *Manager) *ControllerBuilder {
 	c.manager = manager
 	return c
 }
 
-func (c *ControllerBuilder) Create(reconciler string, grpcClient *grpc.RemoteWorkProcessorGrpcClient,
-	isEnabled func() bool) error {
+// Create returns a controller which is not started by the manager, so that it can be started and stopped
+// on its own. It watches the resource through the informers of the manager, which are shared by all controllers.
+func (c *ControllerBuilder) Create(reconciler string, isEnabled func() bool) (controller.Controller, error) {
 	if c.manager == nil || c.selector == nil || c.reconciliationPeriodInMinutes == 0 || c.resource == nil {
-		return fmt.Errorf("controller is missing required parameters")
+		return nil, fmt.Errorf("controller is missing required parameters")
 	}
 
 	gvk := schema.FromAPIVersionAndKind(c.resource.ApiVersion, c.resource.Kind)
 	mapping, err := c.manager.dynamicClient.GetGVR(&gvk)
 	if err != nil {
-		return fmt.Errorf("failed to resolve resource type from kind %+v: %v", gvk, err)
+		return nil, fmt.Errorf("failed to resolve resource type from kind %+v: %v", gvk, err)
 	}
 
 	object := &unstructured.Unstructured{}
 	object.SetGroupVersionKind(gvk)
 
-	err = ctrl.NewControllerManagedBy(c.manager.delegate).
-		For(object).
-		WithEventFilter(c.shouldWatchResource(gvk)).
-		Complete(createReconciler(c.manager.dynamicClient, mapping, reconciler, grpcClient,
-			c.reconciliationPeriodInMinutes, isEnabled))
+	ctrl, err := controller.NewUnmanaged(reconciler, c.manager.delegate, controller.Options{
+		Reconciler: createReconciler(c.manager.dynamicClient, mapping, reconciler, c.manager.eventQueues.For(reconciler),
+			c.manager.delegate.GetEventRecorderFor(EVENT_RECORDER_NAME), c.reconciliationPeriodInMinutes,
+			c.resource.GetFinalizerPolicy(), c.resource.GetFinalizerTimeoutInMinutes(), isEnabled),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create controller: %v", err)
+	}
+
+	err = ctrl.Watch(newInformerSource(object, c.manager.delegate.GetCache()), &handler.EnqueueRequestForObject{},
+		c.shouldWatchResource(gvk))
 	if err != nil {
-		return fmt.Errorf("failed to create controller: %v", err)
+		return nil, fmt.Errorf("failed to watch %+v: %v", gvk, err)
 	}
-	return nil
+	return ctrl, nil
 }
 
 func (c *ControllerBuilder) shouldWatchResource(gvk schema.GroupVersionKind) predicate.Predicate {
@@ -74,7 +93,7 @@ func (c *ControllerBuilder) shouldWatchResource(gvk schema.GroupVersionKind) pre
 			return c.isWatchedResource(e.Object, gvk)
 		},
 		UpdateFunc: func(e event.UpdateEvent) bool {
-			return c.isWatchedResource(e.ObjectNew, gvk)
+			return c.isWatchedResource(e.ObjectNew, gvk) && c.updateFilter.Changed(e.ObjectOld, e.ObjectNew)
 		},
 		DeleteFunc: func(e event.DeleteEvent) bool {
 			return c.isWatchedResource(e.Object, gvk)
@@ -83,9 +102,22 @@ func (c *ControllerBuilder) shouldWatchResource(gvk schema.GroupVersionKind) pre
 }
 
 func (c *ControllerBuilder) isWatchedResource(o client.Object, gvk schema.GroupVersionKind) bool {
+	return isWatched(o, gvk, c.selector, &c.namespaceSelector, c.manager.namespaceLabels)
+}
+
+func isWatched(o client.Object, gvk schema.GroupVersionKind, s *selector.Selector, ns *selector.NamespaceSelector,
+	namespaceLabels selector.NamespaceLabels) bool {
 	return o != nil &&
 		o.GetObjectKind().GroupVersionKind() == gvk &&
-		o.GetNamespace() == c.resource.GetNamespace().GetValue() &&
-		c.selector.LabelSelector.Matches(labels.Set(o.GetLabels())) &&
-		c.selector.FieldSelector.Matches(o)
+		ns.Matches(o.GetNamespace(), namespaceLabels) &&
+		s.LabelSelector.Matches(labels.Set(o.GetLabels())) &&
+		s.FieldSelector.Matches(o)
+}
+
+func namespaceSelectorFor(r *pb.Resource) (selector.NamespaceSelector, error) {
+	namespaces := r.GetNamespaces()
+	if ns := r.GetNamespace().GetValue(); ns != "" {
+		namespaces = append([]string{ns}, namespaces...)
+	}
+	return selector.NewNamespaceSelector(r.GetAllNamespaces(), namespaces, r.GetNamespaceSelector())
 }
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/SAP/remote-work-processor/build/proto/generated"
+	"github.com/SAP/remote-work-processor/internal/kubernetes/dynamic"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// releaseFinalizer removes the finalizer of a deleted object, once the server has finished its cleanup.
+func releaseFinalizer(ctx context.Context, client *dynamic.Client, resource *pb.Resource, request *pb.ReconciliationRequest) error {
+	if resource.GetFinalizerPolicy() != pb.Resource_FINALIZER_POLICY_HOLD_UNTIL_RELEASED {
+		return fmt.Errorf("finalizers are not held until released with finalizer policy %s", resource.GetFinalizerPolicy())
+	}
+
+	mapping, err := mappingFor(client, resource)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, objectUpdateTimeout)
+	defer cancel()
+	objects := client.GetNamespacedResourceInterface(mapping, request.GetResourceNamespace())
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		object, err := objects.Get(ctx, request.GetResourceName(), v1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			// the finalizer has already been removed, e.g. after the release has timed out
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if object.GetDeletionTimestamp().IsZero() {
+			return fmt.Errorf("object is not being deleted")
+		}
+		if !controllerutil.RemoveFinalizer(object, FINALIZER) {
+			return nil
+		}
+		_, err = objects.Update(ctx, object, v1.UpdateOptions{})
+		return err
+	})
+}
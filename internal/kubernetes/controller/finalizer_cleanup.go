@@ -0,0 +1,216 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pb "github.com/SAP/remote-work-processor/build/proto/generated"
+	"github.com/SAP/remote-work-processor/internal/kubernetes/dynamic"
+	"github.com/SAP/remote-work-processor/internal/kubernetes/selector"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientdynamic "k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	cleanupPageSize = 500
+)
+
+// CleanupFinalizers removes the finalizer from all objects in the cluster, e.g. before the Remote Work Processor
+// is uninstalled. Resources which are not allowed to be listed are skipped.
+func CleanupFinalizers(ctx context.Context, client *dynamic.Client) error {
+	logger := log.FromContext(ctx)
+	resources, err := client.GetUpdatableResources()
+	if err != nil {
+		if len(resources) == 0 {
+			return fmt.Errorf("failed to discover resources: %v", err)
+		}
+		logger.Error(err, "Some API groups could not be discovered, their finalizers are not removed")
+	}
+
+	var errs []error
+	for _, gvr := range resources {
+		removed, err := stripFinalizers(ctx, client.GetResourceInterface(gvr), "", v1.ListOptions{},
+			func(*unstructured.Unstructured) bool { return true })
+		if kerrors.IsForbidden(err) || kerrors.IsNotFound(err) || kerrors.IsMethodNotSupported(err) {
+			logger.V(1).Info("Skipping resource", "resource", gvr.String(), "reason", err.Error())
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", gvr.String(), err))
+		}
+		if removed > 0 {
+			logger.Info("Removed finalizers", "resource", gvr.String(), "objects", removed)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// cleanupFinalizers removes the finalizer from the objects which were watched for resources of the previous watch
+// config, unless they are still watched for a resource of the current one.
+func cleanupFinalizers(ctx context.Context, client *dynamic.Client, previous, current map[string]*pb.Resource) {
+	logger := log.FromContext(ctx)
+	namespaceLabels := namespaceLabelsOf(ctx, client)
+
+	for reconciler, resource := range previous {
+		if r, ok := current[reconciler]; ok && proto.Equal(r, resource) {
+			continue
+		}
+
+		removed, err := cleanupFinalizersOf(ctx, client, resource, current, namespaceLabels)
+		if err != nil {
+			logger.Error(err, "Could not remove finalizers of objects no longer watched", "reconciler", reconciler)
+			continue
+		}
+		if removed > 0 {
+			logger.Info("Removed finalizers of objects no longer watched", "reconciler", reconciler, "objects", removed)
+		}
+	}
+}
+
+func cleanupFinalizersOf(ctx context.Context, client *dynamic.Client, resource *pb.Resource, current map[string]*pb.Resource,
+	namespaceLabels selector.NamespaceLabels) (int, error) {
+	watched, err := watchedBy(resource)
+	if err != nil {
+		return 0, err
+	}
+	var stillWatched []func(*unstructured.Unstructured, selector.NamespaceLabels) bool
+	for _, r := range current {
+		if r.GetFinalizerPolicy() == pb.Resource_FINALIZER_POLICY_NONE {
+			// the finalizer would be removed by the reconciler anyway
+			continue
+		}
+		if w, err := watchedBy(r); err == nil {
+			stillWatched = append(stillWatched, w)
+		}
+	}
+
+	mapping, err := mappingFor(client, resource)
+	if err != nil {
+		return 0, err
+	}
+	s, err := selector.NewSelector(resource.GetLabelSelectors(), resource.GetFieldSelectors())
+	if err != nil {
+		return 0, err
+	}
+	ns, err := namespaceSelectorFor(resource)
+	if err != nil {
+		return 0, err
+	}
+	namespaces, ok := ns.CachedNamespaces()
+	if !ok || len(namespaces) == 0 {
+		// all namespaces, or cluster-scoped objects
+		namespaces = []string{""}
+	}
+
+	filter := func(o *unstructured.Unstructured) bool {
+		if !watched(o, namespaceLabels) {
+			return false
+		}
+		for _, w := range stillWatched {
+			if w(o, namespaceLabels) {
+				return false
+			}
+		}
+		return true
+	}
+
+	total := 0
+	for _, namespace := range namespaces {
+		removed, err := stripFinalizers(ctx, client.GetResourceInterface(mapping.Resource), namespace,
+			v1.ListOptions{LabelSelector: s.LabelSelector.String()}, filter)
+		total += removed
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// watchedBy returns whether an object is watched for the resource.
+func watchedBy(resource *pb.Resource) (func(*unstructured.Unstructured, selector.NamespaceLabels) bool, error) {
+	s, err := selector.NewSelector(resource.GetLabelSelectors(), resource.GetFieldSelectors())
+	if err != nil {
+		return nil, err
+	}
+	ns, err := namespaceSelectorFor(resource)
+	if err != nil {
+		return nil, err
+	}
+	gvk := schema.FromAPIVersionAndKind(resource.ApiVersion, resource.Kind)
+	return func(o *unstructured.Unstructured, namespaceLabels selector.NamespaceLabels) bool {
+		return isWatched(o, gvk, s, &ns, namespaceLabels)
+	}, nil
+}
+
+// namespaceLabelsOf looks up the labels of namespaces from the API server, once per namespace.
+func namespaceLabelsOf(ctx context.Context, client *dynamic.Client) selector.NamespaceLabels {
+	namespaces := client.GetResourceInterface(corev1.SchemeGroupVersion.WithResource("namespaces"))
+	cache := make(map[string]labels.Set)
+	return func(namespace string) (labels.Set, error) {
+		if l, ok := cache[namespace]; ok {
+			return l, nil
+		}
+		ns, err := namespaces.Get(ctx, namespace, v1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		cache[namespace] = ns.GetLabels()
+		return ns.GetLabels(), nil
+	}
+}
+
+// stripFinalizers removes the finalizer from the objects in the namespace, or in all namespaces, which pass the filter.
+// It returns the number of objects the finalizer has been removed from.
+func stripFinalizers(ctx context.Context, resource clientdynamic.NamespaceableResourceInterface, namespace string,
+	opts v1.ListOptions, filter func(*unstructured.Unstructured) bool) (int, error) {
+	removed := 0
+	opts.Limit = cleanupPageSize
+	for {
+		list, err := resource.Namespace(namespace).List(ctx, opts)
+		if err != nil {
+			return removed, err
+		}
+
+		for i := range list.Items {
+			o := &list.Items[i]
+			if !controllerutil.ContainsFinalizer(o, FINALIZER) || !filter(o) {
+				continue
+			}
+			if err := removeFinalizerOf(ctx, resource.Namespace(o.GetNamespace()), o.GetName()); err != nil {
+				return removed, fmt.Errorf("failed to remove finalizer of %s/%s: %v", o.GetNamespace(), o.GetName(), err)
+			}
+			removed++
+		}
+
+		if opts.Continue = list.GetContinue(); opts.Continue == "" {
+			return removed, nil
+		}
+	}
+}
+
+func removeFinalizerOf(ctx context.Context, resource clientdynamic.ResourceInterface, name string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		object, err := resource.Get(ctx, name, v1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !controllerutil.RemoveFinalizer(object, FINALIZER) {
+			return nil
+		}
+		_, err = resource.Update(ctx, object, v1.UpdateOptions{})
+		return err
+	})
+}
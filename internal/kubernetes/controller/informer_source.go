@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// informerSource watches objects through a shared informer of the manager, like source.Kind does. Unlike the
+// latter, it removes its event handler from the informer once the controller is stopped, as the informer keeps
+// running for the other controllers and for the controllers started later on.
+type informerSource struct {
+	object client.Object
+	cache  cache.Cache
+
+	// closed once the event handler has been added and the informer has been synced, unless an error occurred
+	started chan error
+}
+
+var _ source.SyncingSource = &informerSource{}
+
+func newInformerSource(object client.Object, cache cache.Cache) *informerSource {
+	return &informerSource{
+		object:  object,
+		cache:   cache,
+		started: make(chan error, 1),
+	}
+}
+
+// Start adds the event handler to the informer and removes it once the context is done.
+func (s *informerSource) Start(ctx context.Context, h handler.EventHandler, queue workqueue.RateLimitingInterface,
+	predicates ...predicate.Predicate) error {
+	go func() {
+		// blocks until the informer is synced if the cache has already been started
+		i, err := s.cache.GetInformer(ctx, s.object)
+		if err != nil {
+			s.started <- fmt.Errorf("failed to get informer from cache: %v", err)
+			return
+		}
+
+		registration, err := i.AddEventHandler(eventHandler{handler: h, queue: queue, predicates: predicates})
+		if err != nil {
+			s.started <- fmt.Errorf("failed to add event handler: %v", err)
+			return
+		}
+		go func() {
+			<-ctx.Done()
+			if err := i.RemoveEventHandler(registration); err != nil {
+				log.FromContext(ctx).Error(err, "Failed to remove event handler", "kind", s.String())
+			}
+		}()
+
+		if !s.cache.WaitForCacheSync(ctx) {
+			s.started <- errors.New("cache did not sync")
+			return
+		}
+		close(s.started)
+	}()
+	return nil
+}
+
+// WaitForSync makes the controller start its workers only once the informer has been synced.
+func (s *informerSource) WaitForSync(ctx context.Context) error {
+	select {
+	case err := <-s.started:
+		return err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return nil
+		}
+		return errors.New("timed out waiting for cache to be synced")
+	}
+}
+
+func (s *informerSource) String() string {
+	return fmt.Sprintf("informer source: %s", s.object.GetObjectKind().GroupVersionKind())
+}
+
+// eventHandler turns the notifications of an informer into the events of a controller, filtered by its predicates.
+type eventHandler struct {
+	handler    handler.EventHandler
+	queue      workqueue.RateLimitingInterface
+	predicates []predicate.Predicate
+}
+
+func (e eventHandler) OnAdd(obj interface{}) {
+	o, ok := obj.(client.Object)
+	if !ok {
+		return
+	}
+
+	c := event.CreateEvent{Object: o}
+	for _, p := range e.predicates {
+		if !p.Create(c) {
+			return
+		}
+	}
+	e.handler.Create(c, e.queue)
+}
+
+func (e eventHandler) OnUpdate(oldObj, newObj interface{}) {
+	old, ok := oldObj.(client.Object)
+	if !ok {
+		return
+	}
+	new, ok := newObj.(client.Object)
+	if !ok {
+		return
+	}
+
+	u := event.UpdateEvent{ObjectOld: old, ObjectNew: new}
+	for _, p := range e.predicates {
+		if !p.Update(u) {
+			return
+		}
+	}
+	e.handler.Update(u, e.queue)
+}
+
+func (e eventHandler) OnDelete(obj interface{}) {
+	// the final state of objects whose deletion has been missed is wrapped
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	o, ok := obj.(client.Object)
+	if !ok {
+		return
+	}
+
+	d := event.DeleteEvent{Object: o}
+	for _, p := range e.predicates {
+		if !p.Delete(d) {
+			return
+		}
+	}
+	e.handler.Delete(d, e.queue)
+}
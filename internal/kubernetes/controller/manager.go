@@ -3,35 +3,145 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
+
 	pb "github.com/SAP/remote-work-processor/build/proto/generated"
-	"github.com/SAP/remote-work-processor/internal/grpc"
 	"github.com/SAP/remote-work-processor/internal/kubernetes/dynamic"
 	"github.com/SAP/remote-work-processor/internal/kubernetes/selector"
-	"log"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
 type Manager struct {
 	delegate      manager.Manager
 	dynamicClient *dynamic.Client
-	grpcClient    *grpc.RemoteWorkProcessorGrpcClient
+	eventQueues   *ReconciliationEventQueues
+	controllers   map[string]*runningController
+	errs          chan error
+}
+
+const (
+	namespaceLookupTimeout = 30 * time.Second
+)
+
+type runningController struct {
+	resource *pb.Resource
+	cancel   context.CancelFunc
+	done     chan struct{}
 }
 
-func (m *Manager) CreateControllersFor(resources map[string]*pb.Resource, isEnabled func() bool) error {
+// UpdateControllers starts, stops and replaces controllers to match the given resources, keyed by the name of their
+// reconciler. The controllers of unchanged resources keep running, and as the informers of the manager are shared,
+// their objects are neither listed nor reconciled again. The informers of kinds which are no longer watched are
+// dropped along with the manager, which is restarted for that reason.
+func (m *Manager) UpdateControllers(ctx context.Context, resources map[string]*pb.Resource, isEnabled func() bool) error {
+	logger := log.FromContext(ctx)
+	for reconciler, c := range m.controllers {
+		if resource, ok := resources[reconciler]; ok && proto.Equal(resource, c.resource) {
+			continue
+		}
+		logger.Info("Stopping controller", "reconciler", reconciler)
+		c.stop()
+		delete(m.controllers, reconciler)
+	}
+
 	for reconciler, resource := range resources {
-		log.Printf("Creating controller for %s/%s watched by %s\n", resource.ApiVersion, resource.Kind, reconciler)
-		err := NewControllerFor(resource).
+		if _, ok := m.controllers[reconciler]; ok {
+			continue
+		}
+		logger.Info("Creating controller", "api_version", resource.ApiVersion, "kind", resource.Kind,
+			"reconciler", reconciler)
+		s, err := selector.NewSelector(resource.GetLabelSelectors(), resource.GetFieldSelectors())
+		if err != nil {
+			return fmt.Errorf("invalid selectors of %s: %v", reconciler, err)
+		}
+		ns, err := namespaceSelectorFor(resource)
+		if err != nil {
+			return fmt.Errorf("invalid namespaces of %s: %v", reconciler, err)
+		}
+		f, err := updateFilterFor(resource)
+		if err != nil {
+			return fmt.Errorf("invalid ignored paths of %s: %v", reconciler, err)
+		}
+
+		c, err := NewControllerFor(resource).
 			ManagedBy(m).
 			WithReconcilicationPeriodInMinutes(resource.ReconciliationPeriodInMinutes).
-			WithSelector(selector.NewSelector(resource.GetLabelSelectors(), resource.GetFieldSelectors())).
-			Create(reconciler, m.grpcClient, isEnabled)
+			WithSelector(s).
+			WithNamespaceSelector(ns).
+			WithUpdateFilter(f).
+			Create(reconciler, isEnabled)
 		if err != nil {
 			return fmt.Errorf("failed to create controller for %s/%s: %s", resource.ApiVersion, resource.Kind, err)
 		}
+		m.start(ctx, reconciler, resource, c)
 	}
 	return nil
 }
 
+// StopControllers stops all controllers and waits for their reconciliations in progress.
+func (m *Manager) StopControllers() {
+	for reconciler, c := range m.controllers {
+		c.stop()
+		delete(m.controllers, reconciler)
+	}
+}
+
+// Errors reports the controllers which have failed to start, e.g. because their informer could not be synced.
+func (m *Manager) Errors() <-chan error {
+	return m.errs
+}
+
+func (m *Manager) start(ctx context.Context, reconciler string, resource *pb.Resource, c controller.Controller) {
+	ctx, cancel := context.WithCancel(ctx)
+	rc := &runningController{
+		resource: resource,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	m.controllers[reconciler] = rc
+
+	go func() {
+		defer close(rc.done)
+		if err := c.Start(ctx); err != nil && ctx.Err() == nil {
+			select {
+			case m.errs <- fmt.Errorf("controller of %s failed: %v", reconciler, err):
+			default:
+			}
+		}
+	}()
+}
+
+func (c *runningController) stop() {
+	c.cancel()
+	<-c.done
+}
+
+// namespaceLabels reads the labels of a namespace from the cache, which requires the permission
+// to list and watch namespaces.
+func (m *Manager) namespaceLabels(namespace string) (labels.Set, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), namespaceLookupTimeout)
+	defer cancel()
+
+	ns := &metav1.PartialObjectMetadata{}
+	ns.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Namespace"))
+	if err := m.delegate.GetClient().Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		return nil, err
+	}
+	return ns.GetLabels(), nil
+}
+
 func (m *Manager) Start(ctx context.Context) error {
 	return m.delegate.Start(ctx)
 }
+
+// Elected is closed once the manager has become the leader, or right after it has been started without leader election.
+func (m *Manager) Elected() <-chan struct{} {
+	return m.delegate.Elected()
+}
@@ -2,36 +2,59 @@ package controller
 
 import (
 	"fmt"
-	"github.com/SAP/remote-work-processor/internal/grpc"
 	"github.com/SAP/remote-work-processor/internal/kubernetes/dynamic"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
-	"log"
 	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"time"
 )
 
 type ManagerBuilder struct {
-	delegate      manager.Manager
-	dynamicClient *dynamic.Client
-	grpcClient    *grpc.RemoteWorkProcessorGrpcClient
+	delegate         manager.Manager
+	dynamicClient    *dynamic.Client
+	eventQueues      *ReconciliationEventQueues
+	leaderElectionID string
+	namespaces       []string
+	selectors        map[schema.GroupVersionKind]cache.ObjectSelector
 }
 
 func NewManagerBuilder() *ManagerBuilder {
 	return &ManagerBuilder{}
 }
 
-func (b *ManagerBuilder) SetGrpcClient(client *grpc.RemoteWorkProcessorGrpcClient) *ManagerBuilder {
-	b.grpcClient = client
+func (b *ManagerBuilder) SetEventQueues(queues *ReconciliationEventQueues) *ManagerBuilder {
+	b.eventQueues = queues
+	return b
+}
+
+// SetLeaderElection makes the controllers run only while the manager holds the Lease with the given name.
+// Leader election is disabled for an empty name.
+func (b *ManagerBuilder) SetLeaderElection(id string) *ManagerBuilder {
+	b.leaderElectionID = id
+	return b
+}
+
+// SetNamespaces restricts the cache to the objects in the given namespaces, along with cluster-scoped ones.
+// The cache spans all namespaces if none are given.
+func (b *ManagerBuilder) SetNamespaces(namespaces []string) *ManagerBuilder {
+	b.namespaces = namespaces
+	return b
+}
+
+// SetSelectors restricts the cache to the objects of the given kinds matching the selectors.
+func (b *ManagerBuilder) SetSelectors(selectors map[schema.GroupVersionKind]cache.ObjectSelector) *ManagerBuilder {
+	b.selectors = selectors
 	return b
 }
 
 func (b *ManagerBuilder) BuildDynamicClient(config *rest.Config) *ManagerBuilder {
 	dc, err := dynamic.NewDynamicClient(config)
 	if err != nil {
-		log.Panicln("Failed to create dynamic client:", err)
+		panic(fmt.Sprintf("Failed to create dynamic client: %v", err))
 	}
 	b.dynamicClient = dc
 	return b
@@ -39,34 +62,52 @@ func (b *ManagerBuilder) BuildDynamicClient(config *rest.Config) *ManagerBuilder
 
 func (b *ManagerBuilder) BuildInternalManager(config *rest.Config, scheme *runtime.Scheme) *ManagerBuilder {
 	t := time.Duration(0)
+	// probes and metrics are served by the Remote Work Processor itself, whether the manager is running or not
 	options := manager.Options{
 		Scheme:                  scheme,
 		GracefulShutdownTimeout: &t,
 		WebhookServer:           nil,
-		LeaderElection:          false,
-		HealthProbeBindAddress:  "localhost:8811",
-		MetricsBindAddress:      "0",
+		LeaderElection:          b.leaderElectionID != "",
+		LeaderElectionID:        b.leaderElectionID,
+		// the lease is handed over right away whenever the manager is stopped for a new watch config
+		LeaderElectionReleaseOnCancel: true,
+		HealthProbeBindAddress:        "0",
+		MetricsBindAddress:            "0",
+	}
+
+	newCache := cache.New
+	if len(b.namespaces) > 0 {
+		newCache = cache.MultiNamespacedCacheBuilder(b.namespaces)
+	}
+	selectorsByObject := make(cache.SelectorsByObject, len(b.selectors))
+	for gvk, s := range b.selectors {
+		object := &unstructured.Unstructured{}
+		object.SetGroupVersionKind(gvk)
+		selectorsByObject[object] = s
+	}
+	options.NewCache = func(config *rest.Config, opts cache.Options) (cache.Cache, error) {
+		opts.SelectorsByObject = selectorsByObject
+		return newCache(config, opts)
 	}
 
 	mgr, err := ctrl.NewManager(config, options)
 	if err != nil {
-		log.Panicln("Failed to create manager:", err)
+		panic(fmt.Sprintf("Failed to create manager: %v", err))
 	}
 
-	// these can fail only if the manager has been started prior to calling them
-	mgr.AddHealthzCheck("healthz", healthz.Ping)
-	mgr.AddReadyzCheck("readyz", healthz.Ping)
 	b.delegate = mgr
 	return b
 }
 
 func (b *ManagerBuilder) Build() (*Manager, error) {
-	if b.delegate == nil || b.dynamicClient == nil || b.grpcClient == nil {
+	if b.delegate == nil || b.dynamicClient == nil || b.eventQueues == nil {
 		return nil, fmt.Errorf("manager is missing required parameters")
 	}
 	return &Manager{
 		delegate:      b.delegate,
 		dynamicClient: b.dynamicClient,
-		grpcClient:    b.grpcClient,
+		eventQueues:   b.eventQueues,
+		controllers:   make(map[string]*runningController),
+		errs:          make(chan error, 1),
 	}, nil
 }
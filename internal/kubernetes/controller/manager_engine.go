@@ -3,68 +3,258 @@ package controller
 import (
 	"context"
 	"fmt"
-	"log"
+	"sync"
 
 	pb "github.com/SAP/remote-work-processor/build/proto/generated"
 	"github.com/SAP/remote-work-processor/internal/grpc"
+	"github.com/SAP/remote-work-processor/internal/kubernetes/dynamic"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 type ManagerEngine struct {
+	sync.RWMutex
 	watchedResources map[string]*pb.Resource
-	grpcClient       *grpc.RemoteWorkProcessorGrpcClient
+	configVersion    string
+	eventQueues      *ReconciliationEventQueues
 	scheme           *runtime.Scheme
 	config           *rest.Config
+	dynamicClient    *dynamic.Client
+	updates          chan struct{}
 
-	running   bool
-	cancelCtx context.CancelFunc
+	running bool
+
+	leaderElectionID string
+	leader           bool
 }
 
-func CreateManagerEngine(scheme *runtime.Scheme, config *rest.Config, client *grpc.RemoteWorkProcessorGrpcClient) *ManagerEngine {
+func CreateManagerEngine(scheme *runtime.Scheme, config *rest.Config, dynamicClient *dynamic.Client,
+	client *grpc.RemoteWorkProcessorGrpcClient) *ManagerEngine {
 	return &ManagerEngine{
-		grpcClient: client,
-		scheme:     scheme,
-		config:     config,
+		eventQueues:   NewReconciliationEventQueues(client),
+		scheme:        scheme,
+		config:        config,
+		dynamicClient: dynamicClient,
+		updates:       make(chan struct{}, 1),
+	}
+}
+
+// EnableLeaderElection makes only the replica holding the Lease with the given name run the controllers.
+func (e *ManagerEngine) EnableLeaderElection(id string) *ManagerEngine {
+	e.leaderElectionID = id
+	return e
+}
+
+// ValidateWatchConfiguration returns the validation errors of the resources, by the name of their reconciler.
+func (e *ManagerEngine) ValidateWatchConfiguration(wc *pb.UpdateConfigRequestMessage) map[string]error {
+	errs := make(map[string]error)
+	for reconciler, resource := range wc.GetResources() {
+		if err := ValidateResource(resource); err != nil {
+			errs[reconciler] = err
+		}
 	}
+	return errs
 }
 
-func (e *ManagerEngine) SetWatchConfiguration(wc *pb.UpdateConfigRequestMessage) {
+// SetWatchConfiguration reports whether the watch is already running, in which case only the controllers of the
+// reconcilers which have been added, removed or changed are updated. Otherwise, the watch is considered running
+// from now on and has to be started with WatchResources.
+func (e *ManagerEngine) SetWatchConfiguration(wc *pb.UpdateConfigRequestMessage) bool {
+	e.Lock()
 	e.watchedResources = wc.Resources
+	e.configVersion = wc.ConfigVersion
+	running := e.running
+	e.running = true
+	e.Unlock()
+	e.eventQueues.Retain(wc.Resources)
+
+	select {
+	case e.updates <- struct{}{}:
+	default:
+		// an update is already pending, it applies the latest watch config anyway
+	}
+	return running
 }
 
+// WatchResources blocks until the context is cancelled. The watch is restarted from the last watch config
+// when the namespaces or selectors the cache of the manager is restricted to change, and when a leader
+// loses its Lease, competing for the leadership again.
 func (e *ManagerEngine) WatchResources(ctx context.Context, isEnabled func() bool) error {
-	if len(e.watchedResources) == 0 {
-		return fmt.Errorf("no resources to watch")
+	defer func() {
+		e.Lock()
+		e.running = false
+		e.Unlock()
+	}()
+
+	for {
+		restart, err := e.runManager(ctx, isEnabled)
+		if !restart {
+			return err
+		}
 	}
+}
 
-	log.Println("Creating manager...")
+// runManager reports whether the manager has been stopped to be restarted.
+func (e *ManagerEngine) runManager(ctx context.Context, isEnabled func() bool) (bool, error) {
+	watchedResources := e.GetWatchedResources()
+	if len(watchedResources) == 0 {
+		// e.g. all reconcilers have been removed before a restart, the manager is started with the next watch config
+		select {
+		case <-e.updates:
+			return true, nil
+		case <-ctx.Done():
+			return false, nil
+		}
+	}
+
+	cache := cacheOptionsFor(watchedResources)
+	engineCtx := ctx
+	logger := log.FromContext(ctx)
+	logger.Info("Creating manager...", "namespaces", cache.namespaces)
 	manager, err := NewManagerBuilder().
-		SetGrpcClient(e.grpcClient).
+		SetEventQueues(e.eventQueues).
+		SetLeaderElection(e.leaderElectionID).
+		SetNamespaces(cache.namespaces).
+		SetSelectors(cache.selectors).
 		BuildDynamicClient(e.config).
 		BuildInternalManager(e.config, e.scheme).
 		Build()
 	if err != nil {
-		return err
+		return false, err
 	}
 
-	log.Println("Creating controllers...")
-	if err := manager.CreateControllersFor(e.watchedResources, isEnabled); err != nil {
-		return fmt.Errorf("failed to create controllers: %v", err)
+	if e.leaderElectionID != "" {
+		logger.Info("Starting manager, controllers are started once the leadership is acquired...", "lease", e.leaderElectionID)
+	} else {
+		logger.Info("Starting manager...")
 	}
-
 	ctx, cancel := context.WithCancel(ctx)
-	e.running = true
-	e.cancelCtx = cancel
+	defer cancel()
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- manager.Start(ctx)
+	}()
+
+	select {
+	case <-manager.Elected():
+	case err := <-stopped:
+		return false, err
+	}
+	if e.leaderElectionID != "" {
+		logger.Info("Acquired leadership, starting controllers...", "lease", e.leaderElectionID)
+	}
+	e.setLeader(true)
+	defer e.setLeader(false)
+	defer manager.StopControllers()
+
+	var previous map[string]*pb.Resource
+	for {
+		if err := manager.UpdateControllers(ctx, watchedResources, isEnabled); err != nil {
+			cancel()
+			<-stopped
+			return false, fmt.Errorf("failed to create controllers: %v", err)
+		}
+		if previous != nil {
+			// the controllers of removed reconcilers are stopped, their finalizers would never be removed
+			go cleanupFinalizers(engineCtx, e.dynamicClient, previous, watchedResources)
+			previous = nil
+		}
 
-	log.Println("Starting manager...")
-	return manager.Start(ctx)
+		select {
+		case <-e.updates:
+			previous, watchedResources = watchedResources, e.GetWatchedResources()
+			// without any resources, the manager keeps running idle, as there is nothing to restrict the cache to
+			updated := cacheOptionsFor(watchedResources)
+			if len(watchedResources) > 0 && (!updated.equal(cache) || updated.drops(cache)) {
+				// the cache cannot be changed while the manager is running, nor can its informers be stopped
+				logger.Info("Watched kinds, namespaces or selectors changed, restarting the watch...",
+					"config_version", e.GetConfigVersion())
+				manager.StopControllers()
+				go cleanupFinalizers(engineCtx, e.dynamicClient, previous, watchedResources)
+				cancel()
+				return true, <-stopped
+			}
+			if len(watchedResources) > 0 {
+				// the informers of new kinds are started along with their controllers
+				cache = updated
+			}
+			logger.Info("Updating controllers...", "config_version", e.GetConfigVersion())
+		case err := <-manager.Errors():
+			cancel()
+			<-stopped
+			return false, err
+		case err := <-stopped:
+			if e.leaderElectionID == "" || ctx.Err() != nil {
+				return false, err
+			}
+			logger.Info("Lost leadership, restarting the watch...", "reason", fmt.Sprint(err))
+			return true, nil
+		}
+	}
 }
 
-func (e *ManagerEngine) Stop() {
-	e.cancelCtx()
+func (e *ManagerEngine) ReleaseNextEvent(req *pb.NextEventRequestMessage) {
+	e.eventQueues.Release(req.GetReconcilerName())
+}
+
+// SetReconciliationResult sets the outcome of a reconciliation, as reported by the server, as a condition
+// of the reconciled object.
+func (e *ManagerEngine) SetReconciliationResult(ctx context.Context, result *pb.ReconciliationResultMessage) error {
+	resource, ok := e.GetWatchedResources()[result.GetReconcilerName()]
+	if !ok {
+		return fmt.Errorf("unknown reconciler %s", result.GetReconcilerName())
+	}
+	return setReconciliationResult(ctx, e.dynamicClient, resource, result)
+}
+
+// ReleaseFinalizer removes the finalizer of a deleted object, as requested by the server.
+func (e *ManagerEngine) ReleaseFinalizer(ctx context.Context, req *pb.ReleaseFinalizerMessage) error {
+	resource, ok := e.GetWatchedResources()[req.GetReconcilerName()]
+	if !ok {
+		return fmt.Errorf("unknown reconciler %s", req.GetReconcilerName())
+	}
+	return releaseFinalizer(ctx, e.dynamicClient, resource, req.GetRequest())
+}
+
+func (e *ManagerEngine) ResumeEvents() {
+	e.eventQueues.Resume()
+}
+
+func (e *ManagerEngine) GetConfigVersion() string {
+	e.RLock()
+	defer e.RUnlock()
+
+	return e.configVersion
+}
+
+// GetWatchedResources returns the resources of the current watch config, keyed by the name of their reconciler.
+func (e *ManagerEngine) GetWatchedResources() map[string]*pb.Resource {
+	e.RLock()
+	defer e.RUnlock()
+
+	return e.watchedResources
+}
+
+// IsLeader reports whether the controllers are running. It is always the case while the manager is running
+// without leader election.
+func (e *ManagerEngine) IsLeader() bool {
+	e.RLock()
+	defer e.RUnlock()
+
+	return e.leader
+}
+
+func (e *ManagerEngine) setLeader(leader bool) {
+	e.Lock()
+	defer e.Unlock()
+
+	e.leader = leader
 }
 
 func (e *ManagerEngine) IsRunning() bool {
+	e.RLock()
+	defer e.RUnlock()
+
 	return e.running
 }
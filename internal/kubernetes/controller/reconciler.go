@@ -3,17 +3,19 @@ package controller
 import (
 	"context"
 	"encoding/json"
-	stdLog "log"
+	"sync"
 	"time"
 
 	pb "github.com/SAP/remote-work-processor/build/proto/generated"
-	"github.com/SAP/remote-work-processor/internal/grpc"
 	"github.com/SAP/remote-work-processor/internal/kubernetes/dynamic"
+	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	dyn "k8s.io/client-go/dynamic"
+	"k8s.io/apimachinery/pkg/types"
+	clientdynamic "k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -22,6 +24,15 @@ import (
 
 const (
 	FINALIZER = "automation.pilot.sap.com/finalizer"
+
+	// EVENT_RECORDER_NAME is the source of the Kubernetes Events recorded on the reconciled objects
+	EVENT_RECORDER_NAME                 = "remote-work-processor"
+	EVENT_REASON_RECONCILE_EVENT_SENT   = "ReconcileEventSent"
+	EVENT_REASON_RECONCILE_EVENT_FAILED = "ReconcileEventFailed"
+	EVENT_REASON_FINALIZER_ADDED        = "FinalizerAdded"
+	EVENT_REASON_FINALIZER_REMOVED      = "FinalizerRemoved"
+	EVENT_REASON_FINALIZER_FAILED       = "FinalizerUpdateFailed"
+	EVENT_REASON_FINALIZER_TIMED_OUT    = "FinalizerReleaseTimedOut"
 )
 
 type WatchConfigReconciler struct {
@@ -29,19 +40,30 @@ type WatchConfigReconciler struct {
 	mapping                        *meta.RESTMapping
 	reconciler                     string
 	reconcilicationPeriodInMinutes time.Duration
-	grpcClient                     *grpc.RemoteWorkProcessorGrpcClient
+	eventQueue                     *ReconciliationEventQueue
+	recorder                       record.EventRecorder
+	finalizerPolicy                pb.Resource_FinalizerPolicy
+	finalizerTimeout               time.Duration
 	isEnabled                      func() bool
+	// the deleted objects the delete event has been sent for, so that it is not sent again while the finalizer is held
+	deleteEventsSent     map[types.NamespacedName]types.UID
+	deleteEventsSentLock sync.Mutex
 }
 
 func createReconciler(client *dynamic.Client, mapping *meta.RESTMapping, reconciler string,
-	grpcClient *grpc.RemoteWorkProcessorGrpcClient, reconcilicationPeriodInMinutes int32, isEnabled func() bool) reconcile.Reconciler {
+	eventQueue *ReconciliationEventQueue, recorder record.EventRecorder, reconcilicationPeriodInMinutes int32,
+	finalizerPolicy pb.Resource_FinalizerPolicy, finalizerTimeoutInMinutes int32, isEnabled func() bool) reconcile.Reconciler {
 	return &WatchConfigReconciler{
 		Client:                         client,
 		mapping:                        mapping,
 		reconciler:                     reconciler,
-		grpcClient:                     grpcClient,
+		eventQueue:                     eventQueue,
+		recorder:                       recorder,
 		reconcilicationPeriodInMinutes: time.Duration(reconcilicationPeriodInMinutes) * time.Minute,
+		finalizerPolicy:                finalizerPolicy,
+		finalizerTimeout:               time.Duration(finalizerTimeoutInMinutes) * time.Minute,
 		isEnabled:                      isEnabled,
+		deleteEventsSent:               make(map[types.NamespacedName]types.UID),
 	}
 }
 
@@ -50,12 +72,7 @@ func (r *WatchConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{RequeueAfter: r.reconcilicationPeriodInMinutes}, nil
 	}
 
-	var resource dyn.ResourceInterface
-	if r.mapping.Scope.Name() == meta.RESTScopeNameNamespace {
-		resource = r.GetResourceInterface(r.mapping.Resource).Namespace(req.Namespace)
-	} else {
-		resource = r.GetResourceInterface(r.mapping.Resource)
-	}
+	resource := r.GetNamespacedResourceInterface(r.mapping, req.Namespace)
 
 	logger := log.FromContext(ctx)
 
@@ -63,6 +80,7 @@ func (r *WatchConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	if err != nil {
 		if kerrors.IsNotFound(err) {
 			logger.Info("resource not found. Ignoring the reconciliation, because object could be deleted")
+			r.forgetDeleteEvent(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 
@@ -71,24 +89,38 @@ func (r *WatchConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	if object.GetDeletionTimestamp().IsZero() {
-		if !controllerutil.ContainsFinalizer(object, FINALIZER) {
+		if r.finalizerPolicy == pb.Resource_FINALIZER_POLICY_NONE {
+			// e.g. added while the resource was watched with another finalizer policy
+			if err := r.removeFinalizer(ctx, resource, object); err != nil {
+				return ctrl.Result{}, err
+			}
+		} else if !controllerutil.ContainsFinalizer(object, FINALIZER) {
 			controllerutil.AddFinalizer(object, FINALIZER)
 			if _, err := resource.Update(ctx, object, v1.UpdateOptions{}); err != nil {
 				logger.Error(err, "failed to add resource finalizer")
+				r.recorder.Eventf(object, corev1.EventTypeWarning, EVENT_REASON_FINALIZER_FAILED,
+					"Failed to add finalizer %s: %v", FINALIZER, err)
 				return ctrl.Result{}, err
 			}
+			r.recorder.Eventf(object, corev1.EventTypeNormal, EVENT_REASON_FINALIZER_ADDED, "Added finalizer %s", FINALIZER)
 		}
 	} else {
-		if err := r.sendReconciliationEvent(object, pb.ReconcileEventMessage_RECONCILE_TYPE_DELETE); err != nil {
-			return ctrl.Result{}, err
-		}
-
-		if controllerutil.RemoveFinalizer(object, FINALIZER) {
-			if _, err := resource.Update(ctx, object, v1.UpdateOptions{}); err != nil {
-				logger.Error(err, "failed to remove resource finalizer")
+		if !r.isDeleteEventSent(req.NamespacedName, object) {
+			if err := r.sendReconciliationEvent(object, pb.ReconcileEventMessage_RECONCILE_TYPE_DELETE); err != nil {
 				return ctrl.Result{}, err
 			}
+			r.setDeleteEventSent(req.NamespacedName, object)
 		}
+
+		if hold, requeueAfter := r.holdFinalizer(object); hold {
+			// later reconciliations only check whether the finalizer has been released or its release has timed out
+			logger.V(1).Info("Holding finalizer until it is released by the server")
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+		if err := r.removeFinalizer(ctx, resource, object); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.forgetDeleteEvent(req.NamespacedName)
 		return ctrl.Result{RequeueAfter: r.reconcilicationPeriodInMinutes}, nil
 	}
 
@@ -98,6 +130,65 @@ func (r *WatchConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	return ctrl.Result{RequeueAfter: r.reconcilicationPeriodInMinutes}, nil
 }
 
+// holdFinalizer reports whether the finalizer of the deleted object is held until the server releases it,
+// and when to check again whether the release has timed out.
+func (r *WatchConfigReconciler) holdFinalizer(object *unstructured.Unstructured) (bool, time.Duration) {
+	if r.finalizerPolicy != pb.Resource_FINALIZER_POLICY_HOLD_UNTIL_RELEASED || !controllerutil.ContainsFinalizer(object, FINALIZER) {
+		return false, 0
+	}
+	if r.finalizerTimeout == 0 {
+		return true, r.reconcilicationPeriodInMinutes
+	}
+
+	remaining := r.finalizerTimeout - time.Since(object.GetDeletionTimestamp().Time)
+	if remaining <= 0 {
+		r.recorder.Eventf(object, corev1.EventTypeWarning, EVENT_REASON_FINALIZER_TIMED_OUT,
+			"Finalizer %s has not been released within %s", FINALIZER, r.finalizerTimeout)
+		return false, 0
+	}
+	return true, min(remaining, r.reconcilicationPeriodInMinutes)
+}
+
+// isDeleteEventSent reports whether the delete event of the object has been sent already. The events are tracked
+// in memory, hence the delete event of an object whose finalizer is held is sent again after a restart.
+func (r *WatchConfigReconciler) isDeleteEventSent(name types.NamespacedName, object *unstructured.Unstructured) bool {
+	r.deleteEventsSentLock.Lock()
+	defer r.deleteEventsSentLock.Unlock()
+
+	uid, ok := r.deleteEventsSent[name]
+	return ok && uid == object.GetUID()
+}
+
+func (r *WatchConfigReconciler) setDeleteEventSent(name types.NamespacedName, object *unstructured.Unstructured) {
+	r.deleteEventsSentLock.Lock()
+	defer r.deleteEventsSentLock.Unlock()
+
+	r.deleteEventsSent[name] = object.GetUID()
+}
+
+func (r *WatchConfigReconciler) forgetDeleteEvent(name types.NamespacedName) {
+	r.deleteEventsSentLock.Lock()
+	defer r.deleteEventsSentLock.Unlock()
+
+	delete(r.deleteEventsSent, name)
+}
+
+func (r *WatchConfigReconciler) removeFinalizer(ctx context.Context, resource clientdynamic.ResourceInterface,
+	object *unstructured.Unstructured) error {
+	if !controllerutil.RemoveFinalizer(object, FINALIZER) {
+		return nil
+	}
+
+	if _, err := resource.Update(ctx, object, v1.UpdateOptions{}); err != nil {
+		log.FromContext(ctx).Error(err, "failed to remove resource finalizer")
+		r.recorder.Eventf(object, corev1.EventTypeWarning, EVENT_REASON_FINALIZER_FAILED,
+			"Failed to remove finalizer %s: %v", FINALIZER, err)
+		return err
+	}
+	r.recorder.Eventf(object, corev1.EventTypeNormal, EVENT_REASON_FINALIZER_REMOVED, "Removed finalizer %s", FINALIZER)
+	return nil
+}
+
 func (r *WatchConfigReconciler) sendReconciliationEvent(object *unstructured.Unstructured,
 	reconcileType pb.ReconcileEventMessage_ReconcileType) error {
 	serialized, err := json.Marshal(object)
@@ -105,18 +196,19 @@ func (r *WatchConfigReconciler) sendReconciliationEvent(object *unstructured.Uns
 		return err
 	}
 
-	msg := newReconciliationEvent(
+	event := newReconciliationEvent(
 		ofType(reconcileType),
 		withContent(string(serialized)),
 		withResourceVersion(object.GetResourceVersion()),
 		withReconcilerName(r.reconciler),
 		withReconciliationRequest(object.GetName(), object.GetNamespace()),
-	).toProtoMessage()
+		withRecorder(object, r.recorder),
+	)
 
-	err = r.grpcClient.Send(msg)
-	if err != nil {
-		// the gRPC connection has broken down, need to reestablish or restart the process
-		stdLog.Printf("could not send reconciliation event message: %v\n", err)
+	// the event is sent once the server requests it, a full queue makes the reconciliation to be retried
+	if err := r.eventQueue.Push(event); err != nil {
+		event.recordSendFailed(err)
+		return err
 	}
 	return nil
 }
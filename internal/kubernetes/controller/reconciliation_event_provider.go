@@ -1,17 +1,26 @@
 package controller
 
 import (
+	"fmt"
+	"strconv"
+
 	pb "github.com/SAP/remote-work-processor/build/proto/generated"
 	"github.com/SAP/remote-work-processor/internal/functional"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type ReconciliationEvent struct {
 	msg *pb.ClientMessage_ReconcileEvent
+	// the Kubernetes Events about the reconciliation event are recorded on the object, if any
+	object   *corev1.ObjectReference
+	recorder record.EventRecorder
 }
 
 func newReconciliationEvent(opts ...functional.Option[ReconciliationEvent]) *ReconciliationEvent {
 	re := &ReconciliationEvent{
-		&pb.ClientMessage_ReconcileEvent{
+		msg: &pb.ClientMessage_ReconcileEvent{
 			ReconcileEvent: &pb.ReconcileEventMessage{},
 		},
 	}
@@ -29,6 +38,53 @@ func (re *ReconciliationEvent) toProtoMessage() *pb.ClientMessage {
 	}
 }
 
+func (re *ReconciliationEvent) key() string {
+	req := re.msg.ReconcileEvent.GetReconciliationRequest()
+	return req.GetResourceNamespace() + "/" + req.GetResourceName()
+}
+
+// isOlderThan compares the resource versions of the objects of both events. Resource versions are opaque,
+// hence only the ones the API server has set as integers, which is the case with etcd, are compared.
+func (re *ReconciliationEvent) isOlderThan(other *ReconciliationEvent) bool {
+	v, err := strconv.ParseUint(re.msg.ReconcileEvent.GetResourceVersion(), 10, 64)
+	if err != nil {
+		return false
+	}
+	otherV, err := strconv.ParseUint(other.msg.ReconcileEvent.GetResourceVersion(), 10, 64)
+	if err != nil {
+		return false
+	}
+	return v < otherV
+}
+
+func (re *ReconciliationEvent) recordSent() {
+	if re.recorder == nil {
+		return
+	}
+	re.recorder.Eventf(re.object, corev1.EventTypeNormal, EVENT_REASON_RECONCILE_EVENT_SENT,
+		"Sent %s reconcile event of reconciler %s to SAP Automation Pilot", re.typeName(), re.msg.ReconcileEvent.GetReconcilerName())
+}
+
+func (re *ReconciliationEvent) recordSendFailed(err error) {
+	if re.recorder == nil {
+		return
+	}
+	re.recorder.Eventf(re.object, corev1.EventTypeWarning, EVENT_REASON_RECONCILE_EVENT_FAILED,
+		"Failed to send %s reconcile event of reconciler %s to SAP Automation Pilot: %v", re.typeName(),
+		re.msg.ReconcileEvent.GetReconcilerName(), err)
+}
+
+func (re *ReconciliationEvent) typeName() string {
+	switch re.msg.ReconcileEvent.GetType() {
+	case pb.ReconcileEventMessage_RECONCILE_TYPE_DELETE:
+		return "delete"
+	case pb.ReconcileEventMessage_RECONCILE_TYPE_CREATE_OR_UPDATE:
+		return "create or update"
+	default:
+		return fmt.Sprint(re.msg.ReconcileEvent.GetType())
+	}
+}
+
 func ofType(t pb.ReconcileEventMessage_ReconcileType) functional.Option[ReconciliationEvent] {
 	return func(re *ReconciliationEvent) {
 		re.msg.ReconcileEvent.Type = t
@@ -61,3 +117,19 @@ func withReconciliationRequest(name, namespace string) functional.Option[Reconci
 		}
 	}
 }
+
+// withRecorder keeps only a reference to the object, as the event might be queued for a while
+func withRecorder(object client.Object, recorder record.EventRecorder) functional.Option[ReconciliationEvent] {
+	return func(re *ReconciliationEvent) {
+		gvk := object.GetObjectKind().GroupVersionKind()
+		re.object = &corev1.ObjectReference{
+			APIVersion:      gvk.GroupVersion().String(),
+			Kind:            gvk.Kind,
+			Name:            object.GetName(),
+			Namespace:       object.GetNamespace(),
+			UID:             object.GetUID(),
+			ResourceVersion: object.GetResourceVersion(),
+		}
+		re.recorder = recorder
+	}
+}
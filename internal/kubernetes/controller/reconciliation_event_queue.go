@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"fmt"
+	"sync"
+
+	pb "github.com/SAP/remote-work-processor/build/proto/generated"
+	"github.com/SAP/remote-work-processor/internal/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	DefaultEventQueueCapacity = 1000
+)
+
+type MessageSender interface {
+	Send(msg *pb.ClientMessage) error
+}
+
+// ReconciliationEventQueue holds the reconciliation events of a single reconciler until the server asks for them.
+// The first event is sent right away, every following one is released only when a NextEventRequestMessage
+// for the reconciler is received. Pending events for the same object are deduplicated, keeping the one with the newest resource version.
+type ReconciliationEventQueue struct {
+	sync.Mutex
+	reconciler string
+	capacity   int
+	sender     MessageSender
+	keys       []string
+	events     map[string]*ReconciliationEvent
+	inFlight   bool
+}
+
+func newReconciliationEventQueue(reconciler string, capacity int, sender MessageSender) *ReconciliationEventQueue {
+	return &ReconciliationEventQueue{
+		reconciler: reconciler,
+		capacity:   capacity,
+		sender:     sender,
+		events:     make(map[string]*ReconciliationEvent),
+	}
+}
+
+func (q *ReconciliationEventQueue) Push(event *ReconciliationEvent) error {
+	q.Lock()
+	key := event.key()
+	if queued, ok := q.events[key]; ok {
+		// e.g. a periodic reconciliation of an object read before its last update
+		if !event.isOlderThan(queued) {
+			q.events[key] = event
+		}
+		q.Unlock()
+		return nil
+	}
+
+	if len(q.keys) >= q.capacity {
+		q.Unlock()
+		return fmt.Errorf("reconciliation event queue of %s is full (%d events pending)", q.reconciler, len(q.keys))
+	}
+
+	q.keys = append(q.keys, key)
+	q.events[key] = event
+	next := q.takeNext()
+	q.Unlock()
+
+	q.send(next)
+	return nil
+}
+
+// Release sends the next pending event, if any, as requested by the server.
+func (q *ReconciliationEventQueue) Release() {
+	q.Lock()
+	q.inFlight = false
+	next := q.takeNext()
+	q.Unlock()
+
+	q.send(next)
+}
+
+func (q *ReconciliationEventQueue) Len() int {
+	q.Lock()
+	defer q.Unlock()
+
+	return len(q.keys)
+}
+
+// takeNext removes the event at the head of the queue, unless an event is in flight already.
+// The event is sent without holding the lock, so that a slow stream does not block the reconcilers.
+func (q *ReconciliationEventQueue) takeNext() *ReconciliationEvent {
+	if q.inFlight || len(q.keys) == 0 {
+		return nil
+	}
+
+	key := q.keys[0]
+	event := q.events[key]
+	q.keys = q.keys[1:]
+	delete(q.events, key)
+	q.inFlight = true
+	return event
+}
+
+func (q *ReconciliationEventQueue) send(event *ReconciliationEvent) {
+	if event == nil {
+		return
+	}
+
+	if err := q.sender.Send(event.toProtoMessage()); err != nil {
+		log.Log.Error(err, "could not send reconciliation event message", "reconciler", q.reconciler, "object", event.key())
+		event.recordSendFailed(err)
+		q.requeue(event)
+		return
+	}
+
+	metrics.ReconciliationEventsSentTotal.WithLabelValues(q.reconciler).Inc()
+	event.recordSent()
+}
+
+// requeue puts an event which could not be sent back at the head of the queue, where it is retried on the next
+// push or release, unless a newer event for the same object has been pushed meanwhile.
+func (q *ReconciliationEventQueue) requeue(event *ReconciliationEvent) {
+	q.Lock()
+	defer q.Unlock()
+
+	q.inFlight = false
+	key := event.key()
+	if queued, ok := q.events[key]; ok {
+		if queued.isOlderThan(event) {
+			q.events[key] = event
+		}
+		return
+	}
+	q.keys = append([]string{key}, q.keys...)
+	q.events[key] = event
+}
+
+type ReconciliationEventQueues struct {
+	sync.Mutex
+	sender MessageSender
+	queues map[string]*ReconciliationEventQueue
+}
+
+func NewReconciliationEventQueues(sender MessageSender) *ReconciliationEventQueues {
+	return &ReconciliationEventQueues{
+		sender: sender,
+		queues: make(map[string]*ReconciliationEventQueue),
+	}
+}
+
+func (qs *ReconciliationEventQueues) For(reconciler string) *ReconciliationEventQueue {
+	qs.Lock()
+	defer qs.Unlock()
+
+	q, ok := qs.queues[reconciler]
+	if !ok {
+		q = newReconciliationEventQueue(reconciler, DefaultEventQueueCapacity, qs.sender)
+		qs.queues[reconciler] = q
+	}
+	return q
+}
+
+// Resume forgets about the events in flight, which might have been lost along with a previous session,
+// and sends the next pending event of every reconciler.
+func (qs *ReconciliationEventQueues) Resume() {
+	qs.Lock()
+	queues := make([]*ReconciliationEventQueue, 0, len(qs.queues))
+	for _, q := range qs.queues {
+		queues = append(queues, q)
+	}
+	qs.Unlock()
+
+	for _, q := range queues {
+		q.Release()
+	}
+}
+
+func (qs *ReconciliationEventQueues) Release(reconciler string) {
+	qs.Lock()
+	q, ok := qs.queues[reconciler]
+	qs.Unlock()
+
+	if !ok {
+		log.Log.Info("Received next event request for unknown reconciler. Ignoring...", "reconciler", reconciler)
+		return
+	}
+	q.Release()
+}
+
+// Retain drops the queues, along with their pending events, of all reconcilers that are no longer watched.
+func (qs *ReconciliationEventQueues) Retain(reconcilers map[string]*pb.Resource) {
+	qs.Lock()
+	defer qs.Unlock()
+
+	for name := range qs.queues {
+		if _, ok := reconcilers[name]; !ok {
+			delete(qs.queues, name)
+		}
+	}
+}
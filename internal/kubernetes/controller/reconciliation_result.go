@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/SAP/remote-work-processor/build/proto/generated"
+	"github.com/SAP/remote-work-processor/internal/kubernetes/dynamic"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// CONDITIONS_ANNOTATION holds the conditions of objects without a status subresource, as a JSON list
+	CONDITIONS_ANNOTATION = "automation.pilot.sap.com/conditions"
+
+	// timeout of the updates of objects requested by the server
+	objectUpdateTimeout = 30 * time.Second
+)
+
+// setReconciliationResult sets the outcome of the reconciliation as a condition of the object.
+func setReconciliationResult(ctx context.Context, client *dynamic.Client, resource *pb.Resource,
+	result *pb.ReconciliationResultMessage) error {
+	condition := v1.Condition{
+		Type:    result.GetConditionType(),
+		Status:  v1.ConditionStatus(result.GetStatus()),
+		Reason:  result.GetReason(),
+		Message: result.GetMessage(),
+	}
+	if condition.Type == "" {
+		return fmt.Errorf("condition type is required")
+	}
+	switch condition.Status {
+	case v1.ConditionTrue, v1.ConditionFalse, v1.ConditionUnknown:
+	default:
+		return fmt.Errorf("invalid condition status %q", condition.Status)
+	}
+
+	mapping, err := mappingFor(client, resource)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, objectUpdateTimeout)
+	defer cancel()
+	return setCondition(ctx, client, mapping, result.GetRequest().GetResourceNamespace(),
+		result.GetRequest().GetResourceName(), condition)
+}
+
+// setCondition sets the condition in the status of the object through the status subresource, or in the
+// CONDITIONS_ANNOTATION if the object has no status subresource. An unchanged condition is not written again,
+// so that the update of the object does not trigger a reconciliation over and over.
+func setCondition(ctx context.Context, client *dynamic.Client, mapping *meta.RESTMapping, namespace, name string,
+	condition v1.Condition) error {
+	resource := client.GetNamespacedResourceInterface(mapping, namespace)
+	hasStatus := true
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		object, err := resource.Get(ctx, name, v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		condition.ObservedGeneration = object.GetGeneration()
+
+		if hasStatus {
+			conditions, _, err := unstructured.NestedSlice(object.Object, "status", "conditions")
+			if err != nil {
+				return fmt.Errorf("unexpected status conditions: %v", err)
+			}
+			conditions, changed := setConditionIn(conditions, condition)
+			if !changed {
+				return nil
+			}
+			if err := unstructured.SetNestedSlice(object.Object, conditions, "status", "conditions"); err != nil {
+				return err
+			}
+
+			_, err = resource.UpdateStatus(ctx, object, v1.UpdateOptions{})
+			if !kerrors.IsNotFound(err) {
+				return err
+			}
+			// the object exists, the resource has no status subresource
+			hasStatus = false
+			if object, err = resource.Get(ctx, name, v1.GetOptions{}); err != nil {
+				return err
+			}
+		}
+
+		var conditions []interface{}
+		if annotation, ok := object.GetAnnotations()[CONDITIONS_ANNOTATION]; ok {
+			// numbers are kept as they are, for the comparison with the observed generation
+			decoder := json.NewDecoder(strings.NewReader(annotation))
+			decoder.UseNumber()
+			if err := decoder.Decode(&conditions); err != nil {
+				return fmt.Errorf("unexpected %s annotation: %v", CONDITIONS_ANNOTATION, err)
+			}
+		}
+		conditions, changed := setConditionIn(conditions, condition)
+		if !changed {
+			return nil
+		}
+		serialized, err := json.Marshal(conditions)
+		if err != nil {
+			return err
+		}
+
+		annotations := object.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[CONDITIONS_ANNOTATION] = string(serialized)
+		object.SetAnnotations(annotations)
+		_, err = resource.Update(ctx, object, v1.UpdateOptions{})
+		return err
+	})
+}
+
+// setConditionIn sets the condition in the unstructured conditions, keeping any other fields of the existing one.
+// The transition time is updated only if the status has changed.
+func setConditionIn(conditions []interface{}, condition v1.Condition) ([]interface{}, bool) {
+	now := v1.Now().UTC().Format(time.RFC3339)
+	for _, c := range conditions {
+		existing, ok := c.(map[string]interface{})
+		if !ok || existing["type"] != condition.Type {
+			continue
+		}
+
+		if existing["status"] == string(condition.Status) && existing["reason"] == condition.Reason &&
+			existing["message"] == condition.Message && fmt.Sprint(existing["observedGeneration"]) == fmt.Sprint(condition.ObservedGeneration) {
+			return conditions, false
+		}
+		if existing["status"] != string(condition.Status) {
+			existing["lastTransitionTime"] = now
+		}
+		existing["status"] = string(condition.Status)
+		existing["reason"] = condition.Reason
+		existing["message"] = condition.Message
+		existing["observedGeneration"] = condition.ObservedGeneration
+		return conditions, true
+	}
+
+	return append(conditions, map[string]interface{}{
+		"type":               condition.Type,
+		"status":             string(condition.Status),
+		"reason":             condition.Reason,
+		"message":            condition.Message,
+		"observedGeneration": condition.ObservedGeneration,
+		"lastTransitionTime": now,
+	}), true
+}
+
+func mappingFor(client *dynamic.Client, resource *pb.Resource) (*meta.RESTMapping, error) {
+	gvk := schema.FromAPIVersionAndKind(resource.ApiVersion, resource.Kind)
+	mapping, err := client.GetGVR(&gvk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve resource type from kind %+v: %v", gvk, err)
+	}
+	return mapping, nil
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	pb "github.com/SAP/remote-work-processor/build/proto/generated"
+	"github.com/itchyny/gojq"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// UpdateFilter tells the updates of objects which are worth a reconcile event from the ones changing nothing
+// but the status, the metadata or the ignored paths of a resource.
+type UpdateFilter struct {
+	ignoreStatus   bool
+	ignoreMetadata bool
+	ignoredPaths   []*gojq.Code
+}
+
+// updateFilterFor returns an error listing all ignored paths which are not valid jq expressions.
+func updateFilterFor(r *pb.Resource) (*UpdateFilter, error) {
+	f := &UpdateFilter{
+		ignoreStatus:   r.GetIgnoreStatusUpdates(),
+		ignoreMetadata: r.GetIgnoreMetadataUpdates(),
+	}
+
+	var errs []error
+	for _, p := range r.GetIgnoredPaths() {
+		if _, err := gojq.Parse(p); err != nil {
+			errs = append(errs, fmt.Errorf("invalid ignored path %q: %v", p, err))
+			continue
+		}
+
+		// the path has been parsed on its own, hence it cannot escape the deletion
+		q, err := gojq.Parse("del(" + p + ")")
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid ignored path %q: %v", p, err))
+			continue
+		}
+
+		c, err := gojq.Compile(q)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid ignored path %q: %v", p, err))
+			continue
+		}
+		f.ignoredPaths = append(f.ignoredPaths, c)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return f, nil
+}
+
+// Changed returns whether the update changed anything but what is ignored. All updates are changes unless
+// something is ignored, and so are the ones the ignored paths cannot be evaluated for.
+func (f *UpdateFilter) Changed(old client.Object, new client.Object) bool {
+	if f == nil || (!f.ignoreStatus && !f.ignoreMetadata && len(f.ignoredPaths) == 0) || old == nil || new == nil {
+		return true
+	}
+
+	o, err := f.relevantFields(old)
+	if err != nil {
+		log.Log.Error(err, "Failed to evaluate ignored paths", "name", old.GetName(), "namespace", old.GetNamespace())
+		return true
+	}
+	n, err := f.relevantFields(new)
+	if err != nil {
+		log.Log.Error(err, "Failed to evaluate ignored paths", "name", new.GetName(), "namespace", new.GetNamespace())
+		return true
+	}
+	return !reflect.DeepEqual(o, n)
+}
+
+func (f *UpdateFilter) relevantFields(o client.Object) (any, error) {
+	// the fields of unstructured objects are not copied, but they are shared with the cache
+	fields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o.DeepCopyObject())
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert object to a unstructured one: %v", err)
+	}
+
+	// both change along with any update
+	metadata, _ := fields["metadata"].(map[string]any)
+	delete(metadata, "resourceVersion")
+	delete(metadata, "managedFields")
+
+	if f.ignoreStatus {
+		delete(fields, "status")
+		// the conditions of objects without a status subresource
+		if annotations, ok := metadata["annotations"].(map[string]any); ok {
+			delete(annotations, CONDITIONS_ANNOTATION)
+		}
+	}
+	if f.ignoreMetadata && metadata != nil {
+		// labels decide whether the object is watched, the deletion timestamp whether it is being deleted
+		relevant := make(map[string]any)
+		for _, field := range []string{"labels", "deletionTimestamp"} {
+			if v, ok := metadata[field]; ok {
+				relevant[field] = v
+			}
+		}
+		fields["metadata"] = relevant
+	}
+
+	var result any = fields
+	for _, jq := range f.ignoredPaths {
+		v, ok := jq.Run(result).Next()
+		if !ok {
+			return nil, errors.New("ignored path has no result")
+		}
+		if err, isErr := v.(error); isErr {
+			return nil, err
+		}
+		result = v
+	}
+	return result, nil
+}
@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+
+	pb "github.com/SAP/remote-work-processor/build/proto/generated"
+	"github.com/SAP/remote-work-processor/internal/kubernetes/selector"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ValidateResource returns the errors which prevent the resource from being watched, e.g. invalid selectors.
+func ValidateResource(r *pb.Resource) error {
+	var errs []error
+	if r.GetApiVersion() == "" {
+		errs = append(errs, errors.New("api version is required"))
+	} else if _, err := schema.ParseGroupVersion(r.GetApiVersion()); err != nil {
+		errs = append(errs, fmt.Errorf("invalid api version: %v", err))
+	}
+	if r.GetKind() == "" {
+		errs = append(errs, errors.New("kind is required"))
+	}
+	if r.GetReconciliationPeriodInMinutes() <= 0 {
+		errs = append(errs, fmt.Errorf("reconciliation period must be positive, got %d minutes", r.GetReconciliationPeriodInMinutes()))
+	}
+	if _, ok := pb.Resource_FinalizerPolicy_name[int32(r.GetFinalizerPolicy())]; !ok {
+		errs = append(errs, fmt.Errorf("unknown finalizer policy %d", r.GetFinalizerPolicy()))
+	}
+	if r.GetFinalizerTimeoutInMinutes() < 0 {
+		errs = append(errs, fmt.Errorf("finalizer timeout must not be negative, got %d minutes", r.GetFinalizerTimeoutInMinutes()))
+	}
+	if _, err := selector.NewSelector(r.GetLabelSelectors(), r.GetFieldSelectors()); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := namespaceSelectorFor(r); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := updateFilterFor(r); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
@@ -1,6 +1,8 @@
 package dynamic
 
 import (
+	"strings"
+
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
@@ -11,8 +13,9 @@ import (
 )
 
 type Client struct {
-	mapper meta.RESTMapper
-	client dynamic.Interface
+	mapper    meta.RESTMapper
+	client    dynamic.Interface
+	discovery discovery.DiscoveryInterface
 }
 
 func NewDynamicClient(config *rest.Config) (*Client, error) {
@@ -20,7 +23,9 @@ func NewDynamicClient(config *rest.Config) (*Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	dc := &Client{}
+	dc := &Client{
+		discovery: discoveryClient,
+	}
 	dc.mapper = restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
 
 	if dc.client, err = dynamic.NewForConfig(config); err != nil {
@@ -33,6 +38,38 @@ func (dc *Client) GetResourceInterface(resource schema.GroupVersionResource) dyn
 	return dc.client.Resource(resource)
 }
 
+func (dc *Client) GetNamespacedResourceInterface(mapping *meta.RESTMapping, namespace string) dynamic.ResourceInterface {
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return dc.client.Resource(mapping.Resource).Namespace(namespace)
+	}
+	return dc.client.Resource(mapping.Resource)
+}
+
+// GetUpdatableResources returns the preferred version of all resources which can be listed and updated.
+// The resources of API groups which cannot be discovered are left out, along with the error.
+func (dc *Client) GetUpdatableResources() ([]schema.GroupVersionResource, error) {
+	lists, err := dc.discovery.ServerPreferredResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, err
+	}
+
+	var resources []schema.GroupVersionResource
+	for _, list := range discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: []string{"list", "update"}}, lists) {
+		gv, parseErr := schema.ParseGroupVersion(list.GroupVersion)
+		if parseErr != nil {
+			continue
+		}
+		for _, r := range list.APIResources {
+			if strings.Contains(r.Name, "/") {
+				// subresource
+				continue
+			}
+			resources = append(resources, gv.WithResource(r.Name))
+		}
+	}
+	return resources, err
+}
+
 func (dc *Client) GetGVR(gvk *schema.GroupVersionKind) (*meta.RESTMapping, error) {
 	return dc.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
 }
@@ -6,8 +6,13 @@ import (
 )
 
 type ManagerEngine interface {
-	SetWatchConfiguration(wc *pb.UpdateConfigRequestMessage)
+	ValidateWatchConfiguration(wc *pb.UpdateConfigRequestMessage) map[string]error
+	SetWatchConfiguration(wc *pb.UpdateConfigRequestMessage) bool
 	WatchResources(ctx context.Context, isEnabled func() bool) error
+	ReleaseNextEvent(req *pb.NextEventRequestMessage)
+	SetReconciliationResult(ctx context.Context, result *pb.ReconciliationResultMessage) error
+	ReleaseFinalizer(ctx context.Context, req *pb.ReleaseFinalizerMessage) error
+	GetConfigVersion() string
+	GetWatchedResources() map[string]*pb.Resource
 	IsRunning() bool
-	Stop()
 }
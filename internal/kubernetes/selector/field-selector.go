@@ -1,20 +1,31 @@
 package selector
 
 import (
-	"log"
+	"errors"
+	"fmt"
+	"regexp"
 
 	"github.com/itchyny/gojq"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// nativeFieldSelector matches the jq expressions which the API server can evaluate as field selectors,
+// e.g. .metadata.name == "autopi"
+var nativeFieldSelector = regexp.MustCompile(`^\s*\.metadata\.(name|namespace)\s*(==|!=)\s*"([^"\\]*)"\s*$`)
+
 type FieldSelector struct {
-	jqs []*gojq.Code
+	jqs    []*gojq.Code
+	native []fields.Requirement
 }
 
-func NewFieldSelector(selectors []string) FieldSelector {
+// NewFieldSelector returns an error listing all selectors which are not valid jq expressions.
+func NewFieldSelector(selectors []string) (FieldSelector, error) {
 	if len(selectors) == 0 {
-		return FieldSelector{}
+		return FieldSelector{}, nil
 	}
 
 	//TODO:
@@ -23,28 +34,58 @@ func NewFieldSelector(selectors []string) FieldSelector {
 	// the second elements would be the values to compare with
 
 	var jqs []*gojq.Code
+	var native []fields.Requirement
+	var errs []error
 
 	for _, s := range selectors {
 		q, err := gojq.Parse(s)
 		if err != nil {
-			// Ignored
-			log.Println(err.Error())
+			errs = append(errs, fmt.Errorf("invalid field selector %q: %v", s, err))
 			continue
 		}
 
 		c, err := gojq.Compile(q)
 		if err != nil {
-			// Ignored
-			log.Println(err.Error())
+			errs = append(errs, fmt.Errorf("invalid field selector %q: %v", s, err))
 			continue
 		}
 
 		jqs = append(jqs, c)
+		if r, ok := nativeFieldRequirement(s); ok {
+			native = append(native, r)
+		}
 	}
 
+	if len(errs) > 0 {
+		return FieldSelector{}, errors.Join(errs...)
+	}
 	return FieldSelector{
-		jqs: jqs,
+		jqs:    jqs,
+		native: native,
+	}, nil
+}
+
+// NativeRequirements returns the field selectors the API server can apply when listing and watching objects.
+// They are applied by the client as well, along with all other field selectors.
+func (fs *FieldSelector) NativeRequirements() []fields.Requirement {
+	return fs.native
+}
+
+func nativeFieldRequirement(s string) (fields.Requirement, bool) {
+	m := nativeFieldSelector.FindStringSubmatch(s)
+	if m == nil {
+		return fields.Requirement{}, false
+	}
+
+	operator := selection.Equals
+	if m[2] == "!=" {
+		operator = selection.NotEquals
 	}
+	return fields.Requirement{
+		Field:    "metadata." + m[1],
+		Operator: operator,
+		Value:    m[3],
+	}, true
 }
 
 func (fs *FieldSelector) Matches(o client.Object) bool {
@@ -55,7 +96,7 @@ func (fs *FieldSelector) Matches(o client.Object) bool {
 
 	fields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
 	if err != nil {
-		log.Printf("Failed to convert object to a unstructured one: %v\n", err)
+		log.Log.Error(err, "Failed to convert object to a unstructured one")
 		return false
 	}
 
@@ -1,7 +1,8 @@
 package selector
 
 import (
-	"log"
+	"errors"
+	"fmt"
 
 	"k8s.io/apimachinery/pkg/labels"
 )
@@ -10,26 +11,31 @@ type LabelSelector struct {
 	labels.Selector
 }
 
-func NewLabelSelector(selectors []string) LabelSelector {
+// NewLabelSelector returns an error listing all invalid selectors.
+func NewLabelSelector(selectors []string) (LabelSelector, error) {
 	if len(selectors) == 0 {
 		return LabelSelector{
 			Selector: labels.Everything(),
-		}
+		}, nil
 	}
 
 	ls := labels.NewSelector()
+	var errs []error
 
 	for _, s := range selectors {
 		r, err := labels.ParseToRequirements(s)
 		if err != nil {
-			// Ignored
-			log.Println(err.Error())
+			errs = append(errs, fmt.Errorf("invalid label selector %q: %v", s, err))
+			continue
 		}
 
-		ls = ls.Add(r[0])
+		ls = ls.Add(r...)
 	}
 
+	if len(errs) > 0 {
+		return LabelSelector{}, errors.Join(errs...)
+	}
 	return LabelSelector{
 		Selector: ls,
-	}
+	}, nil
 }
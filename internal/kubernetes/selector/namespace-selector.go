@@ -0,0 +1,70 @@
+package selector
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// NamespaceLabels looks up the labels of a namespace.
+type NamespaceLabels func(namespace string) (labels.Set, error)
+
+// NamespaceSelector selects the namespaces the objects are watched in: the listed ones, the ones with labels
+// matching a selector, or all of them. Cluster-scoped objects, which have no namespace, are selected
+// if nothing else is, or along with all namespaces.
+type NamespaceSelector struct {
+	all        bool
+	namespaces sets.String
+	labels     labels.Selector
+}
+
+func NewNamespaceSelector(all bool, namespaces []string, labelSelector string) (NamespaceSelector, error) {
+	if all {
+		return NamespaceSelector{
+			all: true,
+		}, nil
+	}
+
+	ns := NamespaceSelector{
+		namespaces: sets.NewString(namespaces...),
+	}
+	if labelSelector != "" {
+		s, err := labels.Parse(labelSelector)
+		if err != nil {
+			return NamespaceSelector{}, fmt.Errorf("invalid namespace selector %q: %v", labelSelector, err)
+		}
+		ns.labels = s
+	}
+
+	if ns.namespaces.Len() == 0 && ns.labels == nil {
+		ns.namespaces.Insert("")
+	}
+	return ns, nil
+}
+
+func (s *NamespaceSelector) Matches(namespace string, namespaceLabels NamespaceLabels) bool {
+	if s.all || s.namespaces.Has(namespace) {
+		return true
+	}
+	if s.labels == nil || namespace == "" {
+		return false
+	}
+
+	l, err := namespaceLabels(namespace)
+	if err != nil {
+		log.Log.Error(err, "Failed to get namespace labels", "namespace", namespace)
+		return false
+	}
+	return s.labels.Matches(l)
+}
+
+// CachedNamespaces returns the namespaces the objects have to be cached for, or false if the cache cannot be
+// restricted to a fixed set of namespaces. Cluster-scoped objects are cached regardless of the namespaces.
+func (s *NamespaceSelector) CachedNamespaces() ([]string, bool) {
+	if s.all || s.labels != nil {
+		return nil, false
+	}
+	return s.namespaces.Difference(sets.NewString("")).List(), true
+}
@@ -1,13 +1,32 @@
 package selector
 
+import (
+	"errors"
+
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
 type Selector struct {
 	LabelSelector
 	FieldSelector
 }
 
-func NewSelector(ls []string, fs []string) *Selector {
-	return &Selector{
-		LabelSelector: NewLabelSelector(ls),
-		FieldSelector: NewFieldSelector(fs),
+func NewSelector(ls []string, fs []string) (*Selector, error) {
+	labelSelector, labelErr := NewLabelSelector(ls)
+	fieldSelector, fieldErr := NewFieldSelector(fs)
+	if err := errors.Join(labelErr, fieldErr); err != nil {
+		return nil, err
 	}
+	return &Selector{
+		LabelSelector: labelSelector,
+		FieldSelector: fieldSelector,
+	}, nil
+}
+
+// NativeRequirements returns the requirements the API server can apply when listing and watching objects:
+// the label selectors, and the field selectors comparing the name or the namespace of the objects to a string.
+func (s *Selector) NativeRequirements() (labels.Requirements, []fields.Requirement) {
+	requirements, _ := s.LabelSelector.Requirements()
+	return requirements, s.FieldSelector.NativeRequirements()
 }
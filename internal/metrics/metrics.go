@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "rwp"
+
+	DirectionSent     = "sent"
+	DirectionReceived = "received"
+)
+
+var (
+	TasksTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tasks_total",
+		Help:      "Number of executed remote tasks by task type and final task state",
+	}, []string{"task_type", "task_state"})
+
+	TaskDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "task_duration_seconds",
+		Help:      "Duration of remote task executions by task type and final task state",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	}, []string{"task_type", "task_state"})
+
+	HttpResponsesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_responses_total",
+		Help:      "Number of HTTP responses received by the HTTP executor by status code",
+	}, []string{"status_code"})
+
+	GrpcReconnectsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_reconnects_total",
+		Help:      "Number of attempts to reestablish the session with the server",
+	})
+
+	GrpcHeartbeatFailuresTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_heartbeat_failures_total",
+		Help:      "Number of heartbeats which could not be sent to the server",
+	})
+
+	GrpcMessagesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_messages_total",
+		Help:      "Number of messages exchanged with the server by direction and message type",
+	}, []string{"direction", "message_type"})
+
+	ReconciliationEventsSentTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconciliation_events_sent_total",
+		Help:      "Number of reconciliation events sent to the server by reconciler name",
+	}, []string{"reconciler"})
+
+	ClientCertificateExpiry = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "client_certificate_expiry_timestamp_seconds",
+		Help:      "Expiry of the client certificate used for the connection to the server, as Unix time",
+	})
+)
+
+func init() {
+	// the registry of controller-runtime already holds its own, the Go runtime and the process metrics
+	ctrlmetrics.Registry.MustRegister(
+		TasksTotal,
+		TaskDuration,
+		HttpResponsesTotal,
+		GrpcReconnectsTotal,
+		GrpcHeartbeatFailuresTotal,
+		GrpcMessagesTotal,
+		ReconciliationEventsSentTotal,
+		ClientCertificateExpiry,
+	)
+}
+
+// RecordMessage counts a message exchanged with the server, labeled with the name of its body field.
+func RecordMessage(direction string, msg proto.Message) {
+	GrpcMessagesTotal.WithLabelValues(direction, messageType(msg)).Inc()
+}
+
+func messageType(msg proto.Message) string {
+	m := msg.ProtoReflect()
+	body := m.Descriptor().Oneofs().ByName("body")
+	if body == nil {
+		return "unknown"
+	}
+	if field := m.WhichOneof(body); field != nil {
+		return string(field.Name())
+	}
+	return "unknown"
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	DisabledBindAddress = "0"
+	metricsPath         = "/metrics"
+	shutdownTimeout     = 5 * time.Second
+)
+
+// Serve exposes the metrics on the given address until the context is cancelled.
+// It is a no-op if the address is DisabledBindAddress.
+func Serve(ctx context.Context, bindAddress string) {
+	logger := log.FromContext(ctx)
+	if bindAddress == DisabledBindAddress || bindAddress == "" {
+		logger.Info("Metrics server is disabled")
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsPath, promhttp.HandlerFor(ctrlmetrics.Registry, promhttp.HandlerOpts{
+		ErrorHandling: promhttp.HTTPErrorOnError,
+	}))
+	server := &http.Server{
+		Addr:              bindAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	go func() {
+		logger.Info("Serving metrics", "address", bindAddress+metricsPath)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error(err, "Metrics server failed")
+		}
+	}()
+}
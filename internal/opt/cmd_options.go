@@ -14,23 +14,43 @@ import (
 )
 
 type Options struct {
-	DisplayVersion bool
-	StandaloneMode bool
-	InstanceId     string
-	MaxConnRetries uint
-	RetryInterval  time.Duration
-	RetryStrategy  StrategyOpt
+	DisplayVersion    bool
+	StandaloneMode    bool
+	InstanceId        string
+	MaxConnRetries    uint
+	RetryInterval     time.Duration
+	RetryStrategy     StrategyOpt
+	TaskWorkers       uint
+	TaskQueueSize     uint
+	MetricsAddr       string
+	HealthAddr        string
+	DebugStatus       bool
+	LeaderElect       bool
+	LeaderElectID     string
+	CleanupFinalizers bool
+	TraceExporter     string
+	TraceEndpoint     string
 }
 
 type StrategyOpt utils.RetryStrategy
 
 const (
-	standaloneModeOpt = "standalone-mode"
-	instanceIdOpt     = "instance-id"
-	connRetriesOpt    = "conn-retries"
-	versionOpt        = "version"
-	retryIntervalOpt  = "retry-interval"
-	retryStrategyOpt  = "retry-strategy"
+	standaloneModeOpt    = "standalone-mode"
+	instanceIdOpt        = "instance-id"
+	connRetriesOpt       = "conn-retries"
+	versionOpt           = "version"
+	retryIntervalOpt     = "retry-interval"
+	retryStrategyOpt     = "retry-strategy"
+	taskWorkersOpt       = "task-workers"
+	taskQueueSizeOpt     = "task-queue-size"
+	metricsAddrOpt       = "metrics-bind-address"
+	healthAddrOpt        = "health-probe-bind-address"
+	debugStatusOpt       = "enable-debug-status"
+	leaderElectOpt       = "leader-elect"
+	leaderElectIdOpt     = "leader-election-id"
+	cleanupFinalizersOpt = "cleanup-finalizers"
+	traceExporterOpt     = "tracing-exporter"
+	traceEndpointOpt     = "tracing-endpoint"
 )
 
 func (opts *Options) BindFlags(fs *flag.FlagSet) {
@@ -40,29 +60,45 @@ func (opts *Options) BindFlags(fs *flag.FlagSet) {
 		"Whether to run the Remote Work Processor in Standalone mode")
 	fs.StringVar(&opts.InstanceId, instanceIdOpt, hostname,
 		"Instance Identifier for the Remote Work Processor (only applicable for Standalone mode)")
-	fs.UintVar(&opts.MaxConnRetries, connRetriesOpt, 6, "Number of retries for gRPC connection to AutoPi server")
+	fs.UintVar(&opts.MaxConnRetries, connRetriesOpt, 6, "Number of consecutive retries for gRPC connection to AutoPi server")
 	fs.BoolVar(&opts.DisplayVersion, versionOpt, false, "Display binary version and exit")
 	fs.DurationVar(&opts.RetryInterval, retryIntervalOpt, 10*time.Second, "Retry interval for connection attempts")
-	fs.Var(&opts.RetryStrategy, retryStrategyOpt, "Retry strategy for connection attempts [fixed, incr]")
+	fs.Var(&opts.RetryStrategy, retryStrategyOpt, "Retry strategy for connection attempts [fixed, incr, exp]")
+	fs.UintVar(&opts.TaskWorkers, taskWorkersOpt, 10, "Maximum number of remote tasks executed concurrently")
+	fs.UintVar(&opts.TaskQueueSize, taskQueueSizeOpt, 100,
+		"Maximum number of remote tasks waiting for a worker, further ones are reported as retryable failures")
+	fs.StringVar(&opts.MetricsAddr, metricsAddrOpt, ":8080", "The address the metrics endpoint binds to (0 disables it)")
+	fs.StringVar(&opts.HealthAddr, healthAddrOpt, ":8811", "The address the health probe endpoints bind to (0 disables them)")
+	fs.BoolVar(&opts.DebugStatus, debugStatusOpt, false,
+		"Whether to serve the watch config and the state of the controllers on /debug/status, without authentication")
+	fs.BoolVar(&opts.LeaderElect, leaderElectOpt, false,
+		"Whether to run the controllers only on the replica holding the leader Lease (only applicable for Kubernetes mode)")
+	fs.StringVar(&opts.LeaderElectID, leaderElectIdOpt, "remote-work-processor-leader",
+		"Name of the Lease used for leader election, in the namespace of the Remote Work Processor")
+	fs.BoolVar(&opts.CleanupFinalizers, cleanupFinalizersOpt, false,
+		"Remove the finalizer of the Remote Work Processor from all objects in the cluster and exit, e.g. before uninstalling it")
+	fs.StringVar(&opts.TraceExporter, traceExporterOpt, "none", "Exporter of the traces [none, otlp-grpc, otlp-http, stdout]")
+	fs.StringVar(&opts.TraceEndpoint, traceEndpointOpt, "",
+		"URL of the OTLP collector, e.g. http://localhost:4317 (defaults to the OTEL_EXPORTER_OTLP_* environment variables)")
 }
 
 func (opt *StrategyOpt) String() string {
 	if len(*opt) == 0 {
-		return string(utils.RetryStrategyFixed)
+		return string(utils.RetryStrategyExponential)
 	}
 	return string(*opt)
 }
 
 func (opt *StrategyOpt) Get() any {
 	if len(*opt) == 0 {
-		return utils.RetryStrategyFixed
+		return utils.RetryStrategyExponential
 	}
 	return utils.RetryStrategy(*opt)
 }
 
 func (opt *StrategyOpt) Set(value string) error {
 	casted := utils.RetryStrategy(value)
-	if casted != utils.RetryStrategyFixed && casted != utils.RetryStrategyIncremental {
+	if casted != utils.RetryStrategyFixed && casted != utils.RetryStrategyIncremental && casted != utils.RetryStrategyExponential {
 		return errors.New("invalid value for retry-strategy: " + value)
 	}
 	*opt = StrategyOpt(value)
@@ -76,6 +112,7 @@ func (opt *StrategyOpt) Unmarshall() utils.RetryStrategy {
 func getHashedHostname() string {
 	hostname, err := os.Hostname()
 	if err != nil {
+		// flags are bound before the structured logger has been set up
 		log.Printf("could not get hostname: %v\n", err)
 		return uuid.Nil.String()
 	} else {
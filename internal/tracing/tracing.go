@@ -0,0 +1,132 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/SAP/remote-work-processor/internal/executors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type Exporter string
+
+const (
+	ExporterNone     Exporter = "none"
+	ExporterOtlpGrpc Exporter = "otlp-grpc"
+	ExporterOtlpHttp Exporter = "otlp-http"
+	ExporterStdout   Exporter = "stdout"
+)
+
+const (
+	tracerName  = "github.com/SAP/remote-work-processor"
+	serviceName = "remote-work-processor"
+
+	ExecutionIdKey      = attribute.Key("execution_id")
+	ExecutionVersionKey = attribute.Key("execution_version")
+)
+
+type executionKey struct{}
+
+// Setup installs the global tracer provider exporting spans with the given exporter. Without an endpoint, the OTLP
+// exporters honor the standard OTEL_EXPORTER_OTLP_* environment variables. The returned function flushes
+// the pending spans and has to be called on shutdown.
+func Setup(ctx context.Context, exporter Exporter, endpoint string, version string) (func(context.Context) error, error) {
+	if exporter == ExporterNone || exporter == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	spanExporter, err := createExporter(ctx, exporter, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("could not create %s trace exporter: %v", exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", serviceName),
+		attribute.String("service.version", version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("could not create trace resource: %v", err)
+	}
+
+	processor := sdktrace.WithBatcher(spanExporter)
+	if exporter == ExporterStdout {
+		// spans are written as soon as they end, while the secrets of the sensitive task are still redacted
+		processor = sdktrace.WithSyncer(spanExporter)
+	}
+	provider := sdktrace.NewTracerProvider(
+		processor,
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	// errors of the exporter are reported with the rest of the logs
+	otel.SetLogger(log.FromContext(ctx).WithName("otel"))
+	log.FromContext(ctx).Info("Exporting traces", "exporter", exporter)
+	return provider.Shutdown, nil
+}
+
+func createExporter(ctx context.Context, exporter Exporter, endpoint string) (sdktrace.SpanExporter, error) {
+	switch exporter {
+	case ExporterOtlpGrpc:
+		var opts []otlptracegrpc.Option
+		if endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpointURL(endpoint))
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	case ExporterOtlpHttp:
+		var opts []otlptracehttp.Option
+		if endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(endpoint))
+		}
+		return otlptracehttp.New(ctx, opts...)
+	case ExporterStdout:
+		// scrubs the secrets of sensitive tasks, as from the logs
+		return stdouttrace.New(stdouttrace.WithPrettyPrint(), stdouttrace.WithWriter(executors.NewRedactingWriter(os.Stdout)))
+	default:
+		return nil, fmt.Errorf("unsupported exporter %q", exporter)
+	}
+}
+
+// WithExecution stores the remote task execution in the context, so that all spans started from it carry its attributes.
+func WithExecution(ctx context.Context, executionId string, executionVersion int64) context.Context {
+	return context.WithValue(ctx, executionKey{}, []attribute.KeyValue{
+		ExecutionIdKey.String(executionId),
+		ExecutionVersionKey.Int64(executionVersion),
+	})
+}
+
+// Start starts a span with the attributes of the remote task execution the context belongs to, if any.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if execution, ok := ctx.Value(executionKey{}).([]attribute.KeyValue); ok {
+		attrs = append(attrs, execution...)
+	}
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndWithError records the error, if any, on the span and ends it.
+func EndWithError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// InjectHeaders propagates the span of the context to the outgoing HTTP request through the W3C traceparent header.
+func InjectHeaders(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
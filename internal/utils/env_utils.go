@@ -1,15 +1,19 @@
 package utils
 
 import (
-	"log"
+	"fmt"
 	"os"
 	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 func GetRequiredEnv(key string) string {
 	value, present := os.LookupEnv(key)
 	if !present {
-		log.Fatalln("failed to load remote work processor metadata: missing", key)
+		log.Log.Error(fmt.Errorf("missing required environment variable %s", key),
+			"failed to load remote work processor metadata", "key", key)
+		os.Exit(1)
 	}
 	return strings.TrimSpace(value)
 }
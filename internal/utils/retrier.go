@@ -2,8 +2,10 @@ package utils
 
 import (
 	"context"
-	"log"
+	"math/rand"
 	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 type RetryStrategy string
@@ -11,25 +13,28 @@ type RetryStrategy string
 const (
 	RetryStrategyFixed       RetryStrategy = "fixed"
 	RetryStrategyIncremental RetryStrategy = "incr"
+	RetryStrategyExponential RetryStrategy = "exp"
+)
+
+const (
+	maxRetryInterval = 5 * time.Minute
 )
 
 type RetryConfig struct {
 	retryInterval time.Duration
 	retryStrategy RetryStrategy
-	signalChan    chan<- struct{}
 	attempts      uint
 	maxAttempts   uint
 }
 
-func CreateDefaultRetryConfig(signalChan chan<- struct{}) *RetryConfig {
-	return CreateRetryConfig(10*time.Second, RetryStrategyFixed, 6, signalChan)
+func CreateDefaultRetryConfig() *RetryConfig {
+	return CreateRetryConfig(10*time.Second, RetryStrategyExponential, 6)
 }
 
-func CreateRetryConfig(interval time.Duration, strategy RetryStrategy, maxAttempts uint, signalChan chan<- struct{}) *RetryConfig {
+func CreateRetryConfig(interval time.Duration, strategy RetryStrategy, maxAttempts uint) *RetryConfig {
 	return &RetryConfig{
 		retryInterval: interval,
 		retryStrategy: strategy,
-		signalChan:    signalChan,
 		maxAttempts:   maxAttempts,
 	}
 }
@@ -38,24 +43,39 @@ func (conf *RetryConfig) CanRetry() bool {
 	return conf.attempts < conf.maxAttempts
 }
 
+// Reset restores the whole attempt budget, e.g. after a connection has proven to be healthy.
+func (conf *RetryConfig) Reset() {
+	conf.attempts = 0
+}
+
 func (conf *RetryConfig) getNextRetryInterval() time.Duration {
 	attempts := conf.attempts
 	conf.attempts++
-	if conf.retryStrategy == RetryStrategyIncremental {
+	switch conf.retryStrategy {
+	case RetryStrategyIncremental:
 		return time.Duration(float32(attempts+1)*1.75) * conf.retryInterval
+	case RetryStrategyExponential:
+		interval := conf.retryInterval << attempts
+		if interval > maxRetryInterval || interval <= 0 {
+			interval = maxRetryInterval
+		}
+		// equal jitter: keep at least half of the interval and randomize the rest,
+		// so that a fleet of processors does not reconnect in lockstep
+		half := interval / 2
+		return half + time.Duration(rand.Int63n(int64(half)+1))
+	default:
+		return conf.retryInterval
 	}
-	// default: fixed
-	return conf.retryInterval
 }
 
-func Retry(ctx context.Context, config *RetryConfig, err error) {
-	log.Println(err)
+// Retry waits for the next retry interval. It returns false if the context has been cancelled in the meantime.
+func Retry(ctx context.Context, config *RetryConfig, err error) bool {
 	nextRetryInterval := config.getNextRetryInterval()
-	log.Println("retrying after", nextRetryInterval)
+	log.FromContext(ctx).Info("Retrying after error", "error", err.Error(), "retry_interval", nextRetryInterval.String())
 	select {
 	case <-ctx.Done():
-		return
+		return false
 	case <-time.After(nextRetryInterval):
+		return true
 	}
-	config.signalChan <- struct{}{}
 }